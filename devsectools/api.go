@@ -1,19 +1,25 @@
 package devsectools
 
-import "context"
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
 
 // Domain retrieves the parsed domain information from the API.
 //
 // Parameters:
 //   - ctx: Context for handling timeouts and cancellations.
 //   - url: The domain to scan (e.g., "example.com").
+//   - opts: Optional RequestOptions which customize this call.
 //
 // Returns:
 //   - A pointer to a `DomainResponse` struct containing the parsed hostname.
 //   - An error if the request fails.
-func (c *Client) Domain(ctx context.Context, url string) (*DomainResponse, error) {
+func (c *Client) Domain(ctx context.Context, url string, opts ...RequestOption) (*DomainResponse, error) {
 	var response DomainResponse
-	err := c.makeRequest(ctx, "GET", "/domain?url="+url, nil, &response)
+	_, err := c.scan(ctx, "/domain", url, &response, opts...)
 	return &response, err
 }
 
@@ -22,13 +28,14 @@ func (c *Client) Domain(ctx context.Context, url string) (*DomainResponse, error
 // Parameters:
 //   - ctx: Context for handling timeouts and cancellations.
 //   - url: The domain to scan (e.g., "example.com").
+//   - opts: Optional RequestOptions which customize this call.
 //
 // Returns:
 //   - A pointer to a `HttpResponse` struct containing HTTP version support details.
 //   - An error if the request fails.
-func (c *Client) HTTP(ctx context.Context, url string) (*HttpResponse, error) {
+func (c *Client) HTTP(ctx context.Context, url string, opts ...RequestOption) (*HttpResponse, error) {
 	var response HttpResponse
-	err := c.makeRequest(ctx, "GET", "/http?url="+url, nil, &response)
+	_, err := c.scan(ctx, "/http", url, &response, opts...)
 	return &response, err
 }
 
@@ -37,12 +44,84 @@ func (c *Client) HTTP(ctx context.Context, url string) (*HttpResponse, error) {
 // Parameters:
 //   - ctx: Context for handling timeouts and cancellations.
 //   - url: The domain to scan (e.g., "example.com").
+//   - opts: Optional RequestOptions which customize this call.
 //
 // Returns:
 //   - A pointer to a `TlsResponse` struct containing TLS version support details and cipher suites.
 //   - An error if the request fails.
-func (c *Client) TLS(ctx context.Context, url string) (*TlsResponse, error) {
+func (c *Client) TLS(ctx context.Context, url string, opts ...RequestOption) (*TlsResponse, error) {
 	var response TlsResponse
-	err := c.makeRequest(ctx, "GET", "/tls?url="+url, nil, &response)
+	_, err := c.scan(ctx, "/tls", url, &response, opts...)
 	return &response, err
 }
+
+// scan requests a scan of `target` from one of the scan endpoints.
+//
+// Parameters:
+//   - ctx: Context for handling timeouts and cancellations.
+//   - endpoint: The API endpoint path (e.g., "/tls").
+//   - target: The domain to scan (e.g., "example.com"), before normalization.
+//   - result: A pointer to a struct where the response will be unmarshaled.
+//   - opts: Optional RequestOptions which customize this call.
+//
+// Returns:
+//   - The raw response body, exactly as returned by the API.
+//   - An error if the request fails.
+func (c *Client) scan(
+	ctx context.Context,
+	endpoint, target string,
+	result any,
+	opts ...RequestOption,
+) ([]byte, error) {
+	normalized, err := c.normalizeTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := c.makeRequest(ctx, "GET", endpoint, scanQuery(normalized), nil, result, opts...)
+	if r, ok := result.(requestedURLSetter); ok {
+		r.setRequestedURL(target)
+	}
+
+	return raw, err
+}
+
+// requestedURLSetter is implemented by response types which record the URL that was requested.
+type requestedURLSetter interface {
+	setRequestedURL(url string)
+}
+
+func (r *DomainResponse) setRequestedURL(url string) { r.RequestedURL = url }
+func (r *HttpResponse) setRequestedURL(url string)   { r.RequestedURL = url }
+func (r *TlsResponse) setRequestedURL(url string)    { r.RequestedURL = url }
+
+// scanQuery builds the query string of a scan request.
+func scanQuery(target string) url.Values {
+	return url.Values{"url": {target}}
+}
+
+// Post sends a JSON payload to an arbitrary API endpoint and decodes the response. It is intended for
+// endpoints which the SDK doesn't wrap yet, such as submitting scan options. The request goes through the
+// same retry, caching, and error handling as the built-in scans.
+//
+// Parameters:
+//   - ctx: Context for handling timeouts and cancellations.
+//   - path: The API endpoint path, optionally with a query string (e.g., "/scan?async=true").
+//   - payload: The value to marshal as the JSON request body. May be `nil` for an empty body.
+//   - result: A pointer to a value where the response will be unmarshaled. May be `nil` to discard it.
+//   - opts: Optional RequestOptions which customize this call.
+//
+// Returns:
+//   - An error if the request fails or an API error occurs.
+func (c *Client) Post(ctx context.Context, path string, payload, result any, opts ...RequestOption) error {
+	endpoint, rawQuery, _ := strings.Cut(path, "?")
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.makeRequest(ctx, http.MethodPost, endpoint, query, payload, result, opts...)
+
+	return err
+}
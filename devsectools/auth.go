@@ -0,0 +1,290 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+// Authenticator supplies a bearer token for API requests and refreshes it when it expires. It is safe for
+// concurrent use, and may be shared between clients.
+type Authenticator struct {
+	// Refresh obtains a new token. It is called when the API rejects a request with `401 Unauthorized`,
+	// after which the request is retried once with the new token. Concurrent rejections share a single
+	// call. If nil, tokens are never refreshed.
+	Refresh func(ctx context.Context) (token string, err error)
+
+	mu    sync.Mutex
+	token string
+}
+
+// NewAuthenticator creates an Authenticator.
+//
+// Parameters:
+//   - token: The initial bearer token. May be empty, in which case the first request is sent without
+//     credentials and the token is obtained from `refresh` when the API rejects it.
+//   - refresh: A function which obtains a new token. May be `nil` for tokens which never expire.
+//
+// Returns:
+//   - A pointer to the newly created Authenticator.
+func NewAuthenticator(token string, refresh func(ctx context.Context) (string, error)) *Authenticator {
+	return &Authenticator{Refresh: refresh, token: token}
+}
+
+// Token returns the current bearer token.
+func (a *Authenticator) Token() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.token
+}
+
+// refresh replaces the token, unless it has already been replaced since `stale` was read. Holding the lock
+// for the whole refresh makes concurrent callers wait for, and then reuse, a single new token.
+//
+// Parameters:
+//   - ctx: A context to allow cancelling the refresh.
+//   - stale: The token which the API rejected.
+//
+// Returns:
+//   - An error if the token could not be refreshed.
+func (a *Authenticator) refresh(ctx context.Context, stale string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != stale {
+		return nil
+	}
+
+	token, err := a.Refresh(ctx)
+	if err != nil {
+		return err
+	}
+	a.token = token
+
+	return nil
+}
+
+// TokenProvider supplies bearer tokens for API requests. Implementations must be safe for concurrent use.
+type TokenProvider interface {
+	// Token returns a valid token, refreshing it if needed.
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenProviderFunc adapts a function to a TokenProvider. It also adapts an `oauth2.TokenSource`:
+//
+//	devsectools.TokenProviderFunc(func(context.Context) (string, error) {
+//	    token, err := tokenSource.Token()
+//	    if err != nil {
+//	        return "", err
+//	    }
+//	    return token.AccessToken, nil
+//	})
+type TokenProviderFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx).
+func (f TokenProviderFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// tokenKey is the context key for a per-call bearer token.
+type tokenKey struct{}
+
+// ContextWithToken returns a context carrying a bearer token, which is sent instead of the client's
+// credentials (`Authenticator`, `TokenProvider`, or `BasicAuth`) with every request made with it. This lets
+// a multi-tenant service make calls on behalf of many customers through one client. Tokens from the
+// context are never refreshed: a `401 Unauthorized` is returned to the caller.
+//
+// An `Authorization` header set explicitly with `Config.DefaultHeaders` or `WithHeader` takes precedence.
+// Note that `Config.Cache`, if set, is shared by all callers, whatever their token.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - token: The bearer token. An empty token restores the client's credentials.
+//
+// Returns:
+//   - A derived context carrying the token.
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// tokenFromContext returns the bearer token carried by the context, if any.
+func tokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(tokenKey{}).(string)
+
+	return token
+}
+
+// BasicAuth holds HTTP Basic credentials. Its `String` and `LogValue` methods redact the password, so it is
+// safe to log.
+type BasicAuth struct {
+	Username string // The user name.
+	Password string // The password.
+}
+
+// String returns the user name with the password redacted.
+func (b BasicAuth) String() string {
+	return b.Username + ":REDACTED"
+}
+
+// LogValue implements `slog.LogValuer`, redacting the password.
+func (b BasicAuth) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("username", b.Username), slog.String("password", "REDACTED"))
+}
+
+// setAuthorization adds the configured credentials to a request, or the token from `ContextWithToken`. An
+// `Authorization` header which is already set, from `Config.DefaultHeaders` or `WithHeader`, takes
+// precedence.
+//
+// Parameters:
+//   - req: The request to authorize.
+//   - ro: The collected per-call settings.
+//
+// Returns:
+//   - `ErrConflictingAuth` if more than one of Basic credentials, an `Authenticator`, and a `TokenProvider`
+//     apply to the request.
+//   - An error if the `TokenProvider` fails.
+func (c *Client) setAuthorization(req *http.Request, ro *requestOptions) error {
+	if token := tokenFromContext(req.Context()); token != "" {
+		if req.Header.Get("Authorization") == "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		return nil
+	}
+
+	basic := c.config.BasicAuth
+	if ro.basicAuth != nil {
+		basic = ro.basicAuth
+	}
+	auth := c.config.Authenticator
+	provider := c.config.TokenProvider
+
+	configured := 0
+	for _, set := range []bool{basic != nil, auth != nil, provider != nil} {
+		if set {
+			configured++
+		}
+	}
+	if configured > 1 {
+		return ErrConflictingAuth
+	}
+
+	if req.Header.Get("Authorization") != "" {
+		return nil
+	}
+
+	switch {
+	case basic != nil:
+		req.SetBasicAuth(basic.Username, basic.Password)
+	case auth != nil:
+		if token := auth.Token(); token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	case provider != nil:
+		token, err := provider.Token(req.Context())
+		if err != nil {
+			return fmt.Errorf("devsectools: obtaining token: %w", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	return nil
+}
+
+// authenticatedAttempt performs a single logical attempt, refreshing the token and trying once more if the
+// API responds with `401 Unauthorized`. Without `Config.Authenticator` or a `CachingTokenProvider`, it is
+// equivalent to `hedgedAttempt`.
+//
+// Parameters:
+//   - ctx: A context to allow request cancellation or custom timeouts.
+//   - method: The HTTP method (e.g., "GET").
+//   - endpoint: The API endpoint path (e.g., "/domain").
+//   - payload: The request body (set to `nil` for GET requests).
+//   - ro: The collected per-call settings, including the complete query string.
+//
+// Returns:
+//   - The raw response body.
+//   - The response, with its body already read, or `nil` if none was received.
+//   - An error if the request failed, or if the token could not be refreshed.
+func (c *Client) authenticatedAttempt(
+	ctx context.Context,
+	method, endpoint string,
+	payload any,
+	ro *requestOptions,
+) ([]byte, *http.Response, error) {
+	// The client's credentials aren't used, so there is nothing to refresh.
+	if tokenFromContext(ctx) != "" {
+		return c.hedgedAttempt(ctx, method, endpoint, payload, ro)
+	}
+
+	if provider, ok := c.config.TokenProvider.(*CachingTokenProvider); ok {
+		return c.cachedTokenAttempt(ctx, provider, method, endpoint, payload, ro)
+	}
+
+	auth := c.config.Authenticator
+	if auth == nil || auth.Refresh == nil {
+		return c.hedgedAttempt(ctx, method, endpoint, payload, ro)
+	}
+
+	stale := auth.Token()
+
+	body, resp, err := c.hedgedAttempt(ctx, method, endpoint, payload, ro)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		return body, resp, err
+	}
+
+	if refreshErr := auth.refresh(ctx, stale); refreshErr != nil {
+		return body, resp, errors.Join(err, refreshErr)
+	}
+
+	return c.hedgedAttempt(ctx, method, endpoint, payload, ro)
+}
+
+// cachedTokenAttempt performs a single logical attempt with a token from a CachingTokenProvider, fetching
+// a new token and trying once more if the API responds with `401 Unauthorized`.
+//
+// Parameters:
+//   - ctx: A context to allow request cancellation or custom timeouts.
+//   - provider: The client's token provider.
+//   - method: The HTTP method (e.g., "GET").
+//   - endpoint: The API endpoint path (e.g., "/domain").
+//   - payload: The request body (set to `nil` for GET requests).
+//   - ro: The collected per-call settings, including the complete query string.
+//
+// Returns:
+//   - The raw response body.
+//   - The response, with its body already read, or `nil` if none was received.
+//   - An error if the request failed, or if the token could not be fetched.
+func (c *Client) cachedTokenAttempt(
+	ctx context.Context,
+	provider *CachingTokenProvider,
+	method, endpoint string,
+	payload any,
+	ro *requestOptions,
+) ([]byte, *http.Response, error) {
+	stale, err := provider.Token(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("devsectools: obtaining token: %w", err)
+	}
+
+	body, resp, err := c.hedgedAttempt(ctx, method, endpoint, payload, ro)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		return body, resp, err
+	}
+
+	if refreshErr := provider.invalidate(ctx, stale); refreshErr != nil {
+		return body, resp, errors.Join(err, refreshErr)
+	}
+
+	return c.hedgedAttempt(ctx, method, endpoint, payload, ro)
+}
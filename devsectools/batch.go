@@ -0,0 +1,599 @@
+package devsectools
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScanMethod identifies the API method to call for a batch request.
+type ScanMethod string
+
+// Scan methods accepted in batch requests. Methods are matched case-insensitively, so "TLS" and "tls" are
+// equivalent, but the constants are preferred over literal strings. String literals can still be used
+// wherever a ScanMethod is expected; a string variable needs a conversion (e.g., `ScanMethod(m)`).
+const (
+	MethodDomain ScanMethod = "domain" // Calls `Client.Domain`.
+	MethodHTTP   ScanMethod = "http"   // Calls `Client.HTTP`.
+	MethodTLS    ScanMethod = "tls"    // Calls `Client.TLS`.
+)
+
+// canonical returns the method in the lowercase form used by the constants.
+func (m ScanMethod) canonical() ScanMethod {
+	return ScanMethod(strings.ToLower(string(m)))
+}
+
+// BatchRequest represents a single request within a batch operation.
+type BatchRequest struct {
+	Method    ScanMethod  // The API method to call: `MethodDomain`, `MethodHTTP`, or `MethodTLS`.
+	URL       string      // The URL to scan.
+	Result    interface{} // A pointer to store the result.
+	RawResult []byte      // The raw response body, populated only when `BatchOptions.CaptureRaw` is set.
+	Cached    bool        // Whether the result was served from `Config.Cache`.
+	Err       error       // Stores any error encountered.
+
+	// Ctx, if set, is used for this request instead of the batch context, so that per-request values such
+	// as trace or correlation IDs reach the request. The batch context still bounds it: cancelling the
+	// batch cancels this request, and the batch deadline applies if it is earlier than Ctx's own.
+	Ctx context.Context
+
+	// Transform, if set, is called with the decoded result of a successful request, from the request's
+	// goroutine, and its return value is stored in `Result` instead. It lets per-result work (e.g.,
+	// computing a grade) run with the batch's concurrency. An error from it is stored in `Err`. Note that
+	// `CollectDomain`, `CollectHTTP`, and `CollectTLS` report a replaced result as having an unexpected type.
+	Transform func(result any) (any, error)
+
+	status int // The status of the decoded response, or zero if it was cached; used for warnings.
+}
+
+// BatchOptions controls how a batch of requests is dispatched.
+//
+// A zero value means no limits, which matches the behavior of `Batch`.
+type BatchOptions struct {
+	MaxConcurrency    int     // Maximum number of requests in flight across all methods (0 = unlimited).
+	RequestsPerSecond float64 // Maximum number of requests started per second across the batch (0 = unlimited).
+
+	// MethodConcurrency caps the number of requests in flight per method, e.g. {MethodTLS: 5}. Methods
+	// which are absent or mapped to 0 are unlimited.
+	MethodConcurrency map[ScanMethod]int
+
+	// CaptureRaw stores the raw response body in `BatchRequest.RawResult` alongside the decoded result.
+	// Every response body is kept in memory until the batch slice is released, so this roughly doubles
+	// the memory used by large batches (TLS responses are typically several KB each).
+	CaptureRaw bool
+
+	// OverallDeadline bounds the wall-clock time of the whole batch (0 = unlimited). When it passes,
+	// requests still waiting to start fail with `context.DeadlineExceeded`, requests in flight are
+	// cancelled, and the batch returns promptly with whatever completed. It is independent of
+	// `Config.Timeout`, which bounds each request. With a `BatchRunner`, it applies to each `Run`.
+	OverallDeadline time.Duration
+
+	// ValidateFirst checks every request before any are dispatched (known method, non-empty URL). If any
+	// are invalid, nothing is sent and a `*BatchValidationError` listing all of them is returned.
+	ValidateFirst bool
+}
+
+// InvalidBatchRequest describes one entry rejected by batch validation.
+type InvalidBatchRequest struct {
+	Index  int        // The index of the request within the batch.
+	Method ScanMethod // The offending request's method.
+	URL    string     // The offending request's URL.
+	Reason string     // Why the request is invalid.
+}
+
+// BatchValidationError is returned when batch validation finds one or more invalid requests.
+type BatchValidationError struct {
+	Invalid []InvalidBatchRequest // Every invalid request, in batch order.
+}
+
+// Error implements the error interface.
+func (e *BatchValidationError) Error() string {
+	parts := make([]string, 0, len(e.Invalid))
+	for _, inv := range e.Invalid {
+		parts = append(parts, fmt.Sprintf("request %d: %s", inv.Index, inv.Reason))
+	}
+
+	return "devsectools: invalid batch: " + strings.Join(parts, "; ")
+}
+
+// ValidateBatch checks that every request in a batch has a known method and a non-empty URL.
+//
+// Parameters:
+//   - requests: A slice of `BatchRequest` structs defining the API calls.
+//
+// Returns:
+//   - A `*BatchValidationError` listing every invalid request, or `nil` if all are valid.
+func ValidateBatch(requests []BatchRequest) error {
+	var invalid []InvalidBatchRequest
+
+	for i, req := range requests {
+		if _, _, ok := batchEndpoint(req.Method); !ok {
+			invalid = append(invalid, InvalidBatchRequest{
+				Index:  i,
+				Method: req.Method,
+				URL:    req.URL,
+				Reason: fmt.Sprintf("unknown method %q", req.Method),
+			})
+		}
+
+		if strings.TrimSpace(req.URL) == "" {
+			invalid = append(invalid, InvalidBatchRequest{
+				Index:  i,
+				Method: req.Method,
+				URL:    req.URL,
+				Reason: "empty URL",
+			})
+		}
+	}
+
+	if len(invalid) > 0 {
+		return &BatchValidationError{Invalid: invalid}
+	}
+
+	return nil
+}
+
+// Batch executes multiple API requests concurrently using Goroutines.
+//
+// This method improves performance by utilizing concurrency in Go.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - requests: A slice of `BatchRequest` structs defining the API calls.
+//
+// Example Usage:
+//
+//	batchRequests := []devsectools.BatchRequest{
+//	    {Method: devsectools.MethodDomain, URL: "example.com", Result: &devsectools.DomainResponse{}},
+//	    {Method: devsectools.MethodHTTP, URL: "example.com", Result: &devsectools.HttpResponse{}},
+//	    {Method: devsectools.MethodTLS, URL: "example.com", Result: &devsectools.TlsResponse{}},
+//	}
+//
+//	client.Batch(context.Background(), batchRequests)
+//
+//	for _, req := range batchRequests {
+//	    if req.Err != nil {
+//	        log.Printf("Error fetching %s: %v\n", req.Method, req.Err)
+//	        continue
+//	    }
+//	    fmt.Printf("Result for %s: %+v\n", req.Method, req.Result)
+//	}
+func (c *Client) Batch(ctx context.Context, requests []BatchRequest) {
+	_ = c.BatchWithOptions(ctx, requests, BatchOptions{})
+}
+
+// BatchWithOptions executes multiple API requests concurrently, honoring the concurrency and rate limits
+// in `opts`.
+//
+// When `ctx` is cancelled or reaches its deadline, no further requests are started and the method returns
+// as soon as the in-flight requests observe the cancellation. Requests which completed keep their
+// results; every other request has its `Err` set to the context's error (e.g., `context.DeadlineExceeded`).
+//
+// Per-method limits and the overall limit are enforced independently, so a batch configured with
+// `MaxConcurrency: 30` and `MethodConcurrency: map[ScanMethod]int{MethodTLS: 5, MethodDomain: 20}` never
+// has more than 5 TLS requests, 20 domain requests, or 30 requests in total in flight at once.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - requests: A slice of `BatchRequest` structs defining the API calls.
+//   - opts: A `BatchOptions` struct defining the concurrency and rate limits.
+//
+// Returns:
+//   - A `*BatchValidationError` if `opts.ValidateFirst` is set and any request is invalid, in which case
+//     no requests are sent. Otherwise `nil`; per-request errors are stored in each `BatchRequest.Err`.
+func (c *Client) BatchWithOptions(ctx context.Context, requests []BatchRequest, opts BatchOptions) error {
+	if opts.ValidateFirst {
+		if err := ValidateBatch(requests); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+
+	limits := c.newBatchLimits(opts, opts.MaxConcurrency)
+
+	var wg sync.WaitGroup
+	for i := range requests {
+		// Once the context is done, nothing else can succeed, so stop launching work and mark the
+		// remaining requests instead of having every goroutine rediscover the cancellation.
+		if err := contextErr(ctx); err != nil {
+			markBatchErr(requests[i:], err)
+			break
+		}
+
+		wg.Add(1)
+		go func(index int, req *BatchRequest) {
+			defer wg.Done()
+			c.runBatchEntry(ctx, index, req, limits, opts)
+		}(i, &requests[i])
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// BatchFunc runs requests pulled one at a time from a generator, so that inputs of any size can be scanned
+// without building the whole batch in memory. A fixed pool of workers processes the requests, and the
+// generator is only called when a worker is free to take the next request, so it is never drained faster
+// than requests complete.
+//
+// The number of workers is `opts.MaxConcurrency`, or `DefaultBatchWorkers` if unset. The other limits in
+// `opts` apply as with `BatchWithOptions`, except `ValidateFirst`, since the requests aren't known in
+// advance; invalid requests fail individually instead.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - next: Returns the next request, and `false` once there are no more. It is only called from the
+//     calling goroutine.
+//   - handle: Called with each completed request, with its result or error set. Calls are never
+//     concurrent, but are made from worker goroutines and in completion order.
+//   - opts: A `BatchOptions` struct defining the concurrency and rate limits.
+//
+// Returns:
+//   - The context's error if it ended before the generator was exhausted, otherwise `nil`. Every request
+//     returned by `next` is handled, failing with the context's error if it couldn't be dispatched.
+func (c *Client) BatchFunc(
+	ctx context.Context,
+	next func() (*BatchRequest, bool),
+	handle func(*BatchRequest),
+	opts BatchOptions,
+) error {
+	workers := opts.MaxConcurrency
+	if workers <= 0 {
+		workers = DefaultBatchWorkers
+	}
+
+	ctx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+
+	limits := c.newBatchLimits(opts, 0)
+
+	var (
+		wg       sync.WaitGroup
+		handleMu sync.Mutex
+	)
+
+	// Unbuffered, so that `next` is only called once a worker is ready for its result.
+	jobs := make(chan batchJob)
+
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+
+			for job := range jobs {
+				c.runBatchEntry(ctx, job.index, job.req, limits, opts)
+
+				handleMu.Lock()
+				handle(job.req)
+				handleMu.Unlock()
+			}
+		}()
+	}
+
+	var err error
+dispatch:
+	for index := 0; ; index++ {
+		req, ok := next()
+		if !ok {
+			break
+		}
+		if req == nil {
+			continue
+		}
+
+		select {
+		case jobs <- batchJob{index: index, req: req}:
+		case <-ctx.Done():
+			err = contextErr(ctx)
+
+			// The request was already pulled from the generator, so report it rather than lose it.
+			req.Err = err
+			handleMu.Lock()
+			handle(req)
+			handleMu.Unlock()
+
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return err
+}
+
+// batchLimits holds the concurrency and rate limits shared by the requests of a batch.
+type batchLimits struct {
+	global    semaphore
+	perMethod map[ScanMethod]semaphore
+	limiter   *rateLimiter
+}
+
+// withDeadline applies `OverallDeadline` to a batch context.
+//
+// Parameters:
+//   - ctx: The context of the batch.
+//
+// Returns:
+//   - The context bounded by the overall deadline, or `ctx` itself if there is none.
+//   - A function which releases the resources of the derived context. It must be called.
+func (o BatchOptions) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.OverallDeadline <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeoutCause(ctx, o.OverallDeadline, ErrBatchDeadline)
+}
+
+// newBatchLimits creates the limits described by `opts`.
+//
+// Parameters:
+//   - opts: The batch options.
+//   - maxConcurrency: The overall concurrency limit (0 = unlimited).
+//
+// Returns:
+//   - A pointer to the batch limits.
+func (c *Client) newBatchLimits(opts BatchOptions, maxConcurrency int) *batchLimits {
+	limits := &batchLimits{
+		global:    newSemaphore(maxConcurrency),
+		perMethod: make(map[ScanMethod]semaphore, len(opts.MethodConcurrency)),
+		limiter:   newRateLimiter(c.clock, opts.RequestsPerSecond),
+	}
+	for method, n := range opts.MethodConcurrency {
+		limits.perMethod[method.canonical()] = newSemaphore(n)
+	}
+
+	return limits
+}
+
+// runBatchEntry waits for the batch limits to allow a request, then performs it. Panics are recovered and
+// stored on the request. `index` is the position of the request in its batch, for error messages.
+func (c *Client) runBatchEntry(
+	ctx context.Context,
+	index int,
+	req *BatchRequest,
+	limits *batchLimits,
+	opts BatchOptions,
+) {
+	defer c.recoverBatchPanic(req)
+
+	ctx, cancel := requestContext(ctx, req.Ctx)
+	defer cancel()
+
+	// Acquire the narrower per-method slot first so that requests waiting on a busy method
+	// don't hold global slots that other methods could be using.
+	sem := limits.perMethod[req.Method.canonical()]
+	if err := sem.acquire(ctx); err != nil {
+		req.Err = err
+		return
+	}
+	defer sem.release()
+
+	if err := limits.global.acquire(ctx); err != nil {
+		req.Err = err
+		return
+	}
+	defer limits.global.release()
+
+	if err := limits.limiter.wait(ctx); err != nil {
+		req.Err = err
+		return
+	}
+
+	// A slot may have been granted at the same moment the context finished.
+	if err := contextErr(ctx); err != nil {
+		req.Err = err
+		return
+	}
+
+	c.doBatchRequest(ctx, index, req, opts)
+}
+
+// markBatchErr sets the same error on every request in `requests`.
+func markBatchErr(requests []BatchRequest, err error) {
+	for i := range requests {
+		requests[i].Err = err
+	}
+}
+
+// recoverBatchPanic converts a panic in a batch goroutine into an error on that request, so that one bad
+// request can't take down the process. It must be called directly by `defer`.
+func (c *Client) recoverBatchPanic(req *BatchRequest) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	req.Err = fmt.Errorf("%w: %v", ErrPanic, r)
+	c.logger().Error(
+		"recovered from panic in batch request",
+		slog.String("method", string(req.Method)),
+		slog.String("url", req.URL),
+		slog.Any("panic", r),
+		slog.String("stack", string(debug.Stack())),
+	)
+}
+
+// doBatchRequest performs a single batch request and stores its result or error. An unknown method fails
+// with `ErrInvalidMethod`, naming the method and `index`.
+func (c *Client) doBatchRequest(ctx context.Context, index int, req *BatchRequest, opts BatchOptions) {
+	endpoint, result, ok := batchEndpoint(req.Method)
+	if !ok {
+		req.Err = fmt.Errorf("%w %q (request %d)", ErrInvalidMethod, req.Method, index)
+		return
+	}
+
+	raw, err := c.scan(ctx, endpoint, req.URL, result, reportCacheHit(&req.Cached), reportStatus(&req.status))
+	req.Result = result
+	if opts.CaptureRaw {
+		req.RawResult = raw
+	}
+	if err != nil {
+		req.Err = err
+		return
+	}
+
+	if req.Transform != nil {
+		req.Result, req.Err = req.Transform(result)
+	}
+}
+
+// batchEndpoint resolves a batch method to its API endpoint path and a new, empty result value.
+//
+// Parameters:
+//   - method: The batch method, in any case.
+//
+// Returns:
+//   - The API endpoint path (e.g., "/tls").
+//   - A pointer to an empty response struct of the matching type.
+//   - Whether the method is known.
+func batchEndpoint(method ScanMethod) (string, any, bool) {
+	switch method.canonical() {
+	case MethodDomain:
+		return "/domain", &DomainResponse{}, true
+	case MethodHTTP:
+		return "/http", &HttpResponse{}, true
+	case MethodTLS:
+		return "/tls", &TlsResponse{}, true
+	default:
+		return "", nil, false
+	}
+}
+
+// BatchResult is the outcome of one request in a batch run with `RunBatch`.
+type BatchResult struct {
+	Index     int        // The index of the request in the input slice.
+	Method    ScanMethod // The API method that was called.
+	URL       string     // The URL that was scanned.
+	Result    any        // A pointer to the decoded response (e.g., `*TlsResponse`).
+	RawResult []byte     // The raw response body, if captured.
+	Cached    bool       // Whether the result was served from the cache.
+	Err       error      // Any error encountered.
+}
+
+// Domain returns the result as a `DomainResponse`.
+//
+// Returns:
+//   - A pointer to the `DomainResponse`, or `nil`.
+//   - Whether the request succeeded and the result is a `DomainResponse`.
+func (r BatchResult) Domain() (*DomainResponse, bool) {
+	result, ok := r.Result.(*DomainResponse)
+
+	return result, ok && r.Err == nil
+}
+
+// HTTP returns the result as an `HttpResponse`.
+//
+// Returns:
+//   - A pointer to the `HttpResponse`, or `nil`.
+//   - Whether the request succeeded and the result is an `HttpResponse`.
+func (r BatchResult) HTTP() (*HttpResponse, bool) {
+	result, ok := r.Result.(*HttpResponse)
+
+	return result, ok && r.Err == nil
+}
+
+// TLS returns the result as a `TlsResponse`.
+//
+// Returns:
+//   - A pointer to the `TlsResponse`, or `nil`.
+//   - Whether the request succeeded and the result is a `TlsResponse`.
+func (r BatchResult) TLS() (*TlsResponse, bool) {
+	result, ok := r.Result.(*TlsResponse)
+
+	return result, ok && r.Err == nil
+}
+
+// RunBatch executes multiple API requests concurrently, like `Batch`, but leaves `requests` untouched and
+// returns the results in a new slice aligned with the input order.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - requests: A slice of `BatchRequest` structs defining the API calls. It is not modified.
+//
+// Returns:
+//   - One `BatchResult` per request, where `results[i]` corresponds to `requests[i]`.
+func (c *Client) RunBatch(ctx context.Context, requests []BatchRequest) []BatchResult {
+	work := slices.Clone(requests)
+	c.Batch(ctx, work)
+
+	results := make([]BatchResult, len(work))
+	for i, req := range work {
+		results[i] = newBatchResult(i, &req)
+	}
+
+	return results
+}
+
+// newBatchResult converts a completed request into a BatchResult.
+func newBatchResult(index int, req *BatchRequest) BatchResult {
+	return BatchResult{
+		Index:     index,
+		Method:    req.Method,
+		URL:       req.URL,
+		Result:    req.Result,
+		RawResult: req.RawResult,
+		Cached:    req.Cached,
+		Err:       req.Err,
+	}
+}
+
+// BatchSeq executes multiple API requests concurrently and yields each result as soon as it completes,
+// for use with range-over-func:
+//
+//	for res := range client.BatchSeq(ctx, requests) {
+//	    if res.Err != nil {
+//	        continue
+//	    }
+//	    // ...
+//	}
+//
+// Results arrive in completion order; use `BatchResult.Index` to relate them to `requests`, which is not
+// modified. Breaking out of the loop cancels every request still in flight.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - requests: A slice of `BatchRequest` structs defining the API calls.
+//
+// Returns:
+//   - An iterator over the results.
+func (c *Client) BatchSeq(ctx context.Context, requests []BatchRequest) iter.Seq[BatchResult] {
+	return func(yield func(BatchResult) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		work := slices.Clone(requests)
+		limits := c.newBatchLimits(BatchOptions{}, 0)
+
+		// Buffered so that goroutines finishing after the consumer stops never block.
+		completed := make(chan int, len(work))
+
+		var wg sync.WaitGroup
+		for i := range work {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.runBatchEntry(ctx, i, &work[i], limits, BatchOptions{})
+				completed <- i
+			}()
+		}
+
+		go func() {
+			wg.Wait()
+			close(completed)
+		}()
+
+		for i := range completed {
+			if !yield(newBatchResult(i, &work[i])) {
+				return
+			}
+		}
+	}
+}
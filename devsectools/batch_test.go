@@ -0,0 +1,412 @@
+package devsectools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// batchServer is a test API which answers every scan with an empty JSON object after an optional delay,
+// recording the most requests it had in flight at once. Targets starting with "fast." skip the delay.
+type batchServer struct {
+	*httptest.Server
+
+	delay time.Duration
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	paths       map[string]int
+}
+
+func newBatchServer(t testing.TB, delay time.Duration) *batchServer {
+	t.Helper()
+
+	bs := &batchServer{delay: delay, paths: map[string]int{}}
+	bs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs.mu.Lock()
+		bs.inFlight++
+		bs.maxInFlight = max(bs.maxInFlight, bs.inFlight)
+		bs.paths[r.URL.Path]++
+		bs.mu.Unlock()
+
+		defer func() {
+			bs.mu.Lock()
+			bs.inFlight--
+			bs.mu.Unlock()
+		}()
+
+		delay := bs.delay
+		if strings.HasPrefix(r.URL.Query().Get("url"), "fast.") {
+			delay = 0
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(bs.Close)
+
+	return bs
+}
+
+func (bs *batchServer) client() *Client {
+	return NewClient(WithEndpoint(&Endpoint{BaseURL: bs.URL}))
+}
+
+func (bs *batchServer) peak() int {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	return bs.maxInFlight
+}
+
+func TestBatchMethodsAreCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	bs := newBatchServer(t, 0)
+	c := bs.client()
+
+	requests := []BatchRequest{
+		{Method: MethodDomain, URL: "example.com"},
+		{Method: "HTTP", URL: "example.com"},
+		{Method: ScanMethod("Tls"), URL: "example.com"},
+	}
+	c.Batch(context.Background(), requests)
+
+	for i, req := range requests {
+		if req.Err != nil {
+			t.Errorf("request %d error = %v", i, req.Err)
+		}
+	}
+
+	if _, ok := requests[0].Result.(*DomainResponse); !ok {
+		t.Errorf("domain result = %T, want *DomainResponse", requests[0].Result)
+	}
+	if _, ok := requests[1].Result.(*HttpResponse); !ok {
+		t.Errorf("HTTP result = %T, want *HttpResponse", requests[1].Result)
+	}
+	if _, ok := requests[2].Result.(*TlsResponse); !ok {
+		t.Errorf("Tls result = %T, want *TlsResponse", requests[2].Result)
+	}
+}
+
+func TestBatchStatsByMethod(t *testing.T) {
+	t.Parallel()
+
+	var stats BatchStats
+	stats.add([]BatchRequest{
+		{Method: MethodTLS},
+		{Method: "TLS", Err: context.DeadlineExceeded},
+		{Method: MethodHTTP, Cached: true},
+	})
+
+	want := map[ScanMethod]MethodStats{
+		MethodTLS:  {Total: 2, Succeeded: 1, Failed: 1},
+		MethodHTTP: {Total: 1, Succeeded: 1},
+	}
+	if len(stats.ByMethod) != len(want) {
+		t.Errorf("ByMethod = %v, want %v", stats.ByMethod, want)
+	}
+	for method, w := range want {
+		if got := stats.ByMethod[method]; got != w {
+			t.Errorf("ByMethod[%s] = %+v, want %+v", method, got, w)
+		}
+	}
+	if stats.Total != 3 || stats.Failed != 1 || stats.CacheHits != 1 {
+		t.Errorf("stats = %+v, want 3 requests, 1 failure and 1 cache hit", stats)
+	}
+}
+
+func TestBatchMethodConcurrency(t *testing.T) {
+	t.Parallel()
+
+	bs := newBatchServer(t, 20*time.Millisecond)
+	c := bs.client()
+
+	requests := make([]BatchRequest, 6)
+	for i := range requests {
+		requests[i] = BatchRequest{Method: "tls", URL: "example.com"}
+	}
+
+	// The limit applies whatever the case of the key or of the requests' methods.
+	err := c.BatchWithOptions(context.Background(), requests, BatchOptions{
+		MethodConcurrency: map[ScanMethod]int{"TLS": 2},
+	})
+	if err != nil {
+		t.Fatalf("BatchWithOptions() error = %v", err)
+	}
+
+	for i, req := range requests {
+		if req.Err != nil {
+			t.Errorf("request %d error = %v", i, req.Err)
+		}
+	}
+	if peak := bs.peak(); peak > 2 {
+		t.Errorf("%d TLS requests were in flight at once, want at most 2", peak)
+	}
+}
+
+func TestBatchReturnsAtContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	bs := newBatchServer(t, 5*time.Second)
+	c := bs.client()
+
+	requests := make([]BatchRequest, 50)
+	for i := range requests {
+		requests[i] = BatchRequest{Method: MethodTLS, URL: "example.com"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := c.BatchWithOptions(ctx, requests, BatchOptions{MaxConcurrency: 4}); err != nil {
+		t.Fatalf("BatchWithOptions() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("BatchWithOptions() returned after %s, want promptly after the 50ms deadline", elapsed)
+	}
+	for i, req := range requests {
+		if !errors.Is(req.Err, context.DeadlineExceeded) {
+			t.Errorf("request %d error = %v, want context.DeadlineExceeded", i, req.Err)
+		}
+	}
+	if peak := bs.peak(); peak > 4 {
+		t.Errorf("%d requests were in flight at once, want at most 4", peak)
+	}
+}
+
+func TestBatchRecoversPanics(t *testing.T) {
+	t.Parallel()
+
+	bs := newBatchServer(t, 0)
+
+	var logs bytes.Buffer
+	var logsMu sync.Mutex
+	c := NewClientWithConfig(&Config{
+		Endpoint: &Endpoint{BaseURL: bs.URL},
+		Timeout:  DefaultTimeout,
+		Logger:   slog.New(slog.NewTextHandler(&lockedWriter{w: &logs, mu: &logsMu}, nil)),
+	})
+
+	requests := []BatchRequest{
+		{Method: MethodDomain, URL: "example.com"},
+		{Method: MethodTLS, URL: "example.com", Transform: func(any) (any, error) { panic("boom") }},
+		{Method: MethodHTTP, URL: "example.com"},
+	}
+	c.Batch(context.Background(), requests)
+
+	if !errors.Is(requests[1].Err, ErrPanic) || !strings.Contains(requests[1].Err.Error(), "boom") {
+		t.Errorf("panicking request error = %v, want ErrPanic with the recovered value", requests[1].Err)
+	}
+	for _, i := range []int{0, 2} {
+		if requests[i].Err != nil || requests[i].Result == nil {
+			t.Errorf("request %d = (%v, %v), want a result", i, requests[i].Result, requests[i].Err)
+		}
+	}
+
+	logsMu.Lock()
+	defer logsMu.Unlock()
+	if !strings.Contains(logs.String(), "recovered from panic") || !strings.Contains(logs.String(), "stack=") {
+		t.Errorf("log = %q, want the panic and its stack", logs.String())
+	}
+}
+
+// lockedWriter serializes writes from concurrent goroutines.
+type lockedWriter struct {
+	w  *bytes.Buffer
+	mu *sync.Mutex
+}
+
+func (lw *lockedWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	return lw.w.Write(p)
+}
+
+func TestBatchOverallDeadline(t *testing.T) {
+	t.Parallel()
+
+	bs := newBatchServer(t, 5*time.Second)
+	c := bs.client()
+
+	requests := []BatchRequest{
+		{Method: MethodTLS, URL: "fast.example.com"},
+		{Method: MethodTLS, URL: "slow1.example.com"},
+		{Method: MethodTLS, URL: "fast.example.org"},
+		{Method: MethodTLS, URL: "slow2.example.com"},
+		{Method: MethodTLS, URL: "slow3.example.com"},
+	}
+
+	start := time.Now()
+	err := c.BatchWithOptions(context.Background(), requests, BatchOptions{OverallDeadline: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("BatchWithOptions() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("BatchWithOptions() returned after %s, want promptly after the 100ms deadline", elapsed)
+	}
+	for i, req := range requests {
+		fast := strings.HasPrefix(req.URL, "fast.")
+		switch {
+		case fast && req.Err != nil:
+			t.Errorf("request %d (%s) error = %v, want a result", i, req.URL, req.Err)
+		case !fast && !errors.Is(req.Err, ErrTimeout):
+			t.Errorf("request %d (%s) error = %v, want ErrTimeout", i, req.URL, req.Err)
+		}
+	}
+}
+
+func TestBatchTimeoutCauses(t *testing.T) {
+	t.Parallel()
+
+	bs := newBatchServer(t, 5*time.Second)
+
+	t.Run("overall deadline", func(t *testing.T) {
+		t.Parallel()
+
+		requests := []BatchRequest{
+			{Method: MethodTLS, URL: "in-flight.example.com"},
+			{Method: MethodTLS, URL: "waiting.example.com"},
+		}
+		err := bs.client().BatchWithOptions(context.Background(), requests, BatchOptions{
+			MaxConcurrency:  1,
+			OverallDeadline: 50 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("BatchWithOptions() error = %v", err)
+		}
+
+		for i, req := range requests {
+			if !errors.Is(req.Err, ErrBatchDeadline) || errors.Is(req.Err, ErrRequestTimeout) {
+				t.Errorf("request %d error = %v, want ErrBatchDeadline only", i, req.Err)
+			}
+			if !strings.Contains(req.Err.Error(), "batch overall deadline exceeded") {
+				t.Errorf("request %d error = %q, want the cause in the message", i, req.Err)
+			}
+		}
+	})
+
+	t.Run("per-request timeout", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(WithEndpoint(&Endpoint{BaseURL: bs.URL}), WithTimeout(50*time.Millisecond))
+
+		requests := []BatchRequest{{Method: MethodTLS, URL: "example.com"}}
+		err := c.BatchWithOptions(context.Background(), requests, BatchOptions{OverallDeadline: time.Minute})
+		if err != nil {
+			t.Fatalf("BatchWithOptions() error = %v", err)
+		}
+
+		if err := requests[0].Err; !errors.Is(err, ErrRequestTimeout) || errors.Is(err, ErrBatchDeadline) {
+			t.Errorf("error = %v, want ErrRequestTimeout only", err)
+		}
+		if err := requests[0].Err; !strings.Contains(err.Error(), "per-request timeout exceeded") {
+			t.Errorf("error = %q, want the cause in the message", err)
+		}
+	})
+}
+
+func TestBatchInvalidMethod(t *testing.T) {
+	t.Parallel()
+
+	bs := newBatchServer(t, 0)
+
+	requests := []BatchRequest{
+		{Method: MethodTLS, URL: "example.com"},
+		{Method: MethodHTTP, URL: "example.com"},
+		{Method: "certs", URL: "example.com"},
+	}
+	bs.client().Batch(context.Background(), requests)
+
+	err := requests[2].Err
+	if !errors.Is(err, ErrInvalidMethod) {
+		t.Fatalf("error = %v, want ErrInvalidMethod", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, `"certs"`) || !strings.Contains(msg, "request 2") {
+		t.Errorf("error = %q, want the method and the request index", msg)
+	}
+	for i, req := range requests[:2] {
+		if req.Err != nil {
+			t.Errorf("request %d error = %v", i, req.Err)
+		}
+	}
+}
+
+// BenchmarkBatch measures a batch of TLS scans through each of the concurrency and rate limits, reporting
+// how many connections were opened per batch. Responses are drained before their connections are
+// released, so connections are reused across batches even when the API returns errors.
+func BenchmarkBatch(b *testing.B) {
+	body := bytes.Repeat([]byte(" "), 4<<10)
+	body = append(body, `{}`...)
+
+	benchmarks := []struct {
+		name   string
+		status int
+		opts   BatchOptions
+	}{
+		{"Unlimited", http.StatusOK, BatchOptions{}},
+		{"MaxConcurrency", http.StatusOK, BatchOptions{MaxConcurrency: 8}},
+		{"MethodConcurrency", http.StatusOK, BatchOptions{MethodConcurrency: map[ScanMethod]int{MethodTLS: 8}}},
+		{"RequestsPerSecond", http.StatusOK, BatchOptions{MaxConcurrency: 8, RequestsPerSecond: 1e6}},
+		{"Errors", http.StatusNotFound, BatchOptions{MaxConcurrency: 8}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			var conns atomic.Int64
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(bm.status)
+				_, _ = w.Write(body)
+			}))
+			srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					conns.Add(1)
+				}
+			}
+			srv.Start()
+			b.Cleanup(srv.Close)
+
+			c := NewClientWithConfig(&Config{
+				Endpoint:            &Endpoint{BaseURL: srv.URL},
+				Timeout:             DefaultTimeout,
+				MaxIdleConnsPerHost: 32,
+			})
+			b.Cleanup(func() { _ = c.Close() })
+
+			requests := make([]BatchRequest, 32)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for range b.N {
+				for i := range requests {
+					requests[i] = BatchRequest{Method: MethodTLS, URL: "example.com"}
+				}
+				if err := c.BatchWithOptions(context.Background(), requests, bm.opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportMetric(float64(conns.Load())/float64(b.N), "conns/op")
+		})
+	}
+}
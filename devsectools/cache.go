@@ -0,0 +1,144 @@
+package devsectools
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cache stores raw API response bodies, keyed by request. Responses are stored exactly as received and
+// decoded again on every hit, so cached and fresh responses behave identically. Implementations must be
+// safe for concurrent use.
+type Cache interface {
+	// Get returns the cached response for `key`, if present.
+	Get(key string) ([]byte, bool)
+
+	// Set stores a response for `key`.
+	Set(key string, value []byte)
+}
+
+// MemoryCache is an in-memory Cache whose entries expire after a fixed time-to-live. Expired entries are
+// removed when they are next looked up.
+type MemoryCache struct {
+	ttl     time.Duration
+	clock   clock
+	mu      sync.RWMutex
+	entries map[string]memoryCacheEntry
+}
+
+// memoryCacheEntry is a cached response and its expiry.
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates an empty in-memory cache.
+//
+// Parameters:
+//   - ttl: How long entries remain valid. Zero or negative values mean entries never expire.
+//
+// Returns:
+//   - A pointer to the new MemoryCache.
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		ttl:     ttl,
+		clock:   realClock{},
+		entries: make(map[string]memoryCacheEntry),
+	}
+}
+
+// Get returns a copy of the cached response for `key`, if present and not expired.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.RLock()
+	entry, ok := m.entries[key]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	if !entry.expires.IsZero() && !m.clock.Now().Before(entry.expires) {
+		m.mu.Lock()
+		if current, ok := m.entries[key]; ok && current.expires.Equal(entry.expires) {
+			delete(m.entries, key)
+		}
+		m.mu.Unlock()
+
+		return nil, false
+	}
+
+	return append([]byte(nil), entry.value...), true
+}
+
+// Set stores a copy of a response for `key`.
+func (m *MemoryCache) Set(key string, value []byte) {
+	entry := memoryCacheEntry{value: append([]byte(nil), value...)}
+	if m.ttl > 0 {
+		entry.expires = m.clock.Now().Add(m.ttl)
+	}
+
+	m.mu.Lock()
+	m.entries[key] = entry
+	m.mu.Unlock()
+}
+
+// fetch answers a request from the cache when possible, and otherwise sends it, caching a successful
+// response. Only GET requests are cached, and `WithNoCache` bypasses the cache.
+//
+// Parameters:
+//   - ctx: A context to allow request cancellation or custom timeouts.
+//   - method: The HTTP method (e.g., "GET").
+//   - endpoint: The API endpoint path (e.g., "/domain").
+//   - payload: The request body (set to `nil` for GET requests).
+//   - ro: The collected per-call settings, including the complete query string.
+//   - event: The event template describing this call.
+//
+// Returns:
+//   - The raw response body.
+//   - `ErrOfflineMiss` or `ErrOffline` in offline mode, or any error from sending the request.
+func (c *Client) fetch(
+	ctx context.Context,
+	method, endpoint string,
+	payload any,
+	ro *requestOptions,
+	event Event,
+) ([]byte, error) {
+	cache := c.config.Cache
+	if method != http.MethodGet || ((cache == nil || ro.noCache) && !c.config.Offline) {
+		return c.send(ctx, method, endpoint, payload, ro, event)
+	}
+
+	reqURL, err := c.requestURL(endpoint, ro)
+	if err != nil {
+		return nil, err
+	}
+	key := method + " " + reqURL.String()
+
+	if c.config.Offline && (ro.forceRefresh || ro.noCache) {
+		return nil, ErrOffline
+	}
+
+	if cache != nil && !ro.forceRefresh {
+		if body, ok := cache.Get(key); ok {
+			if ro.cacheHit != nil {
+				*ro.cacheHit = true
+			}
+			c.emit(event, EventCacheHit, nil, 0)
+
+			return body, nil
+		}
+		c.recordCacheMiss()
+	}
+
+	if c.config.Offline {
+		return nil, ErrOfflineMiss
+	}
+
+	body, err := c.send(ctx, method, endpoint, payload, ro, event)
+	if err == nil {
+		cache.Set(key, body)
+	}
+
+	return body, err
+}
@@ -0,0 +1,123 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newVersionServer starts a test server whose `/domain` responses carry an increasing version in the
+// hostname ("v1", "v2", ...), so that tests can tell a cached response from a fresh one.
+func newVersionServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var version atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"hostname": "v%d"}`, version.Add(1))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &version
+}
+
+func TestCacheModes(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts       []RequestOption
+		wantResult string // The hostname returned by the call.
+		wantCached string // The hostname served by a normal call afterwards.
+		wantSent   int32  // Requests sent to the API, including populating the cache.
+	}{
+		"normal":        {nil, "v1", "v1", 1},
+		"force refresh": {[]RequestOption{WithForceRefresh()}, "v2", "v2", 2},
+		"no cache":      {[]RequestOption{WithNoCache()}, "v2", "v1", 2},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, sent := newVersionServer(t)
+			c := NewClientWithConfig(&Config{
+				Endpoint: &Endpoint{BaseURL: srv.URL},
+				Timeout:  DefaultTimeout,
+				Cache:    NewMemoryCache(time.Hour),
+			})
+
+			// Populate the cache.
+			if _, err := c.Domain(context.Background(), "example.com"); err != nil {
+				t.Fatalf("Domain() error = %v", err)
+			}
+
+			resp, err := c.Domain(context.Background(), "example.com", tt.opts...)
+			if err != nil {
+				t.Fatalf("Domain() error = %v", err)
+			}
+			if resp.Hostname != tt.wantResult {
+				t.Errorf("Domain() = %q, want %q", resp.Hostname, tt.wantResult)
+			}
+
+			resp, err = c.Domain(context.Background(), "example.com")
+			if err != nil {
+				t.Fatalf("Domain() error = %v", err)
+			}
+			if resp.Hostname != tt.wantCached {
+				t.Errorf("Domain() afterwards = %q, want %q from the cache", resp.Hostname, tt.wantCached)
+			}
+
+			if got := sent.Load(); got != tt.wantSent {
+				t.Errorf("server received %d requests, want %d", got, tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestNoCacheDoesNotPopulate(t *testing.T) {
+	t.Parallel()
+
+	srv, sent := newVersionServer(t)
+	c := NewClientWithConfig(&Config{
+		Endpoint: &Endpoint{BaseURL: srv.URL},
+		Timeout:  DefaultTimeout,
+		Cache:    NewMemoryCache(time.Hour),
+	})
+
+	if _, err := c.Domain(context.Background(), "example.com", WithNoCache()); err != nil {
+		t.Fatalf("Domain() error = %v", err)
+	}
+
+	resp, err := c.Domain(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Domain() error = %v", err)
+	}
+	if resp.Hostname != "v2" || sent.Load() != 2 {
+		t.Errorf("Domain() after WithNoCache = %q with %d requests, want a fresh v2", resp.Hostname, sent.Load())
+	}
+}
+
+func TestNoCacheOffline(t *testing.T) {
+	t.Parallel()
+
+	srv, sent := newVersionServer(t)
+	c := NewClientWithConfig(&Config{
+		Endpoint: &Endpoint{BaseURL: srv.URL},
+		Timeout:  DefaultTimeout,
+		Cache:    NewMemoryCache(time.Hour),
+		Offline:  true,
+	})
+
+	for _, opt := range []RequestOption{WithNoCache(), WithForceRefresh()} {
+		if _, err := c.Domain(context.Background(), "example.com", opt); !errors.Is(err, ErrOffline) {
+			t.Errorf("Domain() error = %v, want ErrOffline", err)
+		}
+	}
+	if got := sent.Load(); got != 0 {
+		t.Errorf("server received %d requests in offline mode", got)
+	}
+}
@@ -0,0 +1,88 @@
+package devsectools
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Canonical renders the scan as stable, line-oriented text, suitable for committing as a baseline and
+// diffing against later scans. The hostname, the enabled TLS versions, and the cipher suites offered under
+// each version are listed; connections are ordered by version and cipher suites by IANA name, so the
+// output doesn't depend on the order of the API's response. Other cipher suite details are omitted.
+//
+// Example output:
+//
+//	hostname: example.com
+//	versions: TLS 1.2, TLS 1.3
+//	TLS 1.2:
+//	  TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256
+//	TLS 1.3:
+//	  TLS_AES_128_GCM_SHA256
+//	  TLS_AES_256_GCM_SHA384
+//
+// Returns:
+//   - The canonical representation, ending in a newline, or "" if `r` is nil.
+func (r *TlsResponse) Canonical() string {
+	if r == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "hostname: %s\n", strings.ToLower(r.Hostname))
+
+	versions := r.EnabledVersions()
+	names := make([]string, len(versions))
+	for i, v := range versions {
+		names[i] = v.String()
+	}
+	fmt.Fprintf(&b, "versions: %s\n", strings.Join(names, ", "))
+
+	conns := slices.Clone(r.connections())
+	slices.SortStableFunc(conns, func(a, b TlsConnection) int {
+		return cmp.Compare(a.VersionID, b.VersionID)
+	})
+
+	for _, conn := range conns {
+		suites := make([]string, 0, len(conn.CipherSuites))
+		for _, suite := range conn.CipherSuites {
+			suites = append(suites, suite.IANAName)
+		}
+		slices.Sort(suites)
+		suites = slices.Compact(suites)
+
+		fmt.Fprintf(&b, "%s:\n", TLSVersion(conn.VersionID))
+		for _, suite := range suites {
+			fmt.Fprintf(&b, "  %s\n", suite)
+		}
+	}
+
+	return b.String()
+}
+
+// Canonical renders the scan as stable, line-oriented text, suitable for committing as a baseline and
+// diffing against later scans.
+//
+// Example output:
+//
+//	hostname: example.com
+//	http/1.1: true
+//	http/2: true
+//	http/3: false
+//
+// Returns:
+//   - The canonical representation, ending in a newline, or "" if `r` is nil.
+func (r *HttpResponse) Canonical() string {
+	if r == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"hostname: %s\nhttp/1.1: %t\nhttp/2: %t\nhttp/3: %t\n",
+		strings.ToLower(r.Hostname),
+		r.HTTP11,
+		r.HTTP2,
+		r.HTTP3,
+	)
+}
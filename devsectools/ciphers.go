@@ -0,0 +1,156 @@
+package devsectools
+
+import (
+	"strings"
+	"sync"
+)
+
+// cipherNames maps IANA cipher suite names to OpenSSL names. It is seeded with the suites commonly seen in
+// scans and extended at runtime by `RegisterCipherSuiteNames`.
+var cipherNames = struct {
+	sync.RWMutex
+	ianaToOpenSSL map[string]string
+	openSSLToIANA map[string]string
+}{
+	ianaToOpenSSL: map[string]string{},
+	openSSLToIANA: map[string]string{},
+}
+
+func init() {
+	for iana, openssl := range map[string]string{
+		// TLS 1.3 (OpenSSL uses the IANA names).
+		"TLS_AES_128_GCM_SHA256":       "TLS_AES_128_GCM_SHA256",
+		"TLS_AES_256_GCM_SHA384":       "TLS_AES_256_GCM_SHA384",
+		"TLS_CHACHA20_POLY1305_SHA256": "TLS_CHACHA20_POLY1305_SHA256",
+		"TLS_AES_128_CCM_SHA256":       "TLS_AES_128_CCM_SHA256",
+		"TLS_AES_128_CCM_8_SHA256":     "TLS_AES_128_CCM_8_SHA256",
+
+		// ECDHE
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256":       "ECDHE-ECDSA-AES128-GCM-SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384":       "ECDHE-ECDSA-AES256-GCM-SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":         "ECDHE-RSA-AES128-GCM-SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":         "ECDHE-RSA-AES256-GCM-SHA384",
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256": "ECDHE-ECDSA-CHACHA20-POLY1305",
+		"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256":   "ECDHE-RSA-CHACHA20-POLY1305",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CCM":              "ECDHE-ECDSA-AES128-CCM",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_CCM":              "ECDHE-ECDSA-AES256-CCM",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256":       "ECDHE-ECDSA-AES128-SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA384":       "ECDHE-ECDSA-AES256-SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256":         "ECDHE-RSA-AES128-SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA384":         "ECDHE-RSA-AES256-SHA384",
+		"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA":          "ECDHE-ECDSA-AES128-SHA",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":          "ECDHE-ECDSA-AES256-SHA",
+		"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":            "ECDHE-RSA-AES128-SHA",
+		"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":            "ECDHE-RSA-AES256-SHA",
+		"TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA":           "ECDHE-RSA-DES-CBC3-SHA",
+		"TLS_ECDHE_RSA_WITH_RC4_128_SHA":                "ECDHE-RSA-RC4-SHA",
+
+		// DHE
+		"TLS_DHE_RSA_WITH_AES_128_GCM_SHA256":       "DHE-RSA-AES128-GCM-SHA256",
+		"TLS_DHE_RSA_WITH_AES_256_GCM_SHA384":       "DHE-RSA-AES256-GCM-SHA384",
+		"TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256": "DHE-RSA-CHACHA20-POLY1305",
+		"TLS_DHE_RSA_WITH_AES_128_CBC_SHA256":       "DHE-RSA-AES128-SHA256",
+		"TLS_DHE_RSA_WITH_AES_256_CBC_SHA256":       "DHE-RSA-AES256-SHA256",
+		"TLS_DHE_RSA_WITH_AES_128_CBC_SHA":          "DHE-RSA-AES128-SHA",
+		"TLS_DHE_RSA_WITH_AES_256_CBC_SHA":          "DHE-RSA-AES256-SHA",
+
+		// Static RSA key exchange
+		"TLS_RSA_WITH_AES_128_GCM_SHA256":   "AES128-GCM-SHA256",
+		"TLS_RSA_WITH_AES_256_GCM_SHA384":   "AES256-GCM-SHA384",
+		"TLS_RSA_WITH_AES_128_CBC_SHA256":   "AES128-SHA256",
+		"TLS_RSA_WITH_AES_256_CBC_SHA256":   "AES256-SHA256",
+		"TLS_RSA_WITH_AES_128_CBC_SHA":      "AES128-SHA",
+		"TLS_RSA_WITH_AES_256_CBC_SHA":      "AES256-SHA",
+		"TLS_RSA_WITH_CAMELLIA_128_CBC_SHA": "CAMELLIA128-SHA",
+		"TLS_RSA_WITH_CAMELLIA_256_CBC_SHA": "CAMELLIA256-SHA",
+		"TLS_RSA_WITH_3DES_EDE_CBC_SHA":     "DES-CBC3-SHA",
+		"TLS_RSA_WITH_RC4_128_SHA":          "RC4-SHA",
+	} {
+		registerCipherName(iana, openssl)
+	}
+}
+
+// registerCipherName records an IANA/OpenSSL name pair. The caller must not hold the lock.
+func registerCipherName(iana, openssl string) {
+	cipherNames.Lock()
+	defer cipherNames.Unlock()
+
+	cipherNames.ianaToOpenSSL[strings.ToUpper(iana)] = openssl
+	cipherNames.openSSLToIANA[strings.ToUpper(openssl)] = iana
+}
+
+// RegisterCipherSuiteNames adds the IANA/OpenSSL name pairs reported in scan results to the lookup table
+// used by `IANAToOpenSSL` and `OpenSSLToIANA`, so suites missing from the built-in table can be translated.
+//
+// Parameters:
+//   - suites: Cipher suites from scan results (e.g., from `TlsConnection.CipherSuites`).
+func RegisterCipherSuiteNames(suites ...CipherSuite) {
+	for _, suite := range suites {
+		if suite.IANAName != "" && suite.OpenSSLName != "" {
+			registerCipherName(suite.IANAName, suite.OpenSSLName)
+		}
+	}
+}
+
+// IANAToOpenSSL translates an IANA cipher suite name (e.g., "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256") to its
+// OpenSSL name (e.g., "ECDHE-RSA-AES128-GCM-SHA256"). The lookup is case-insensitive.
+//
+// Parameters:
+//   - name: The IANA cipher suite name.
+//
+// Returns:
+//   - The OpenSSL name.
+//   - Whether the name is known.
+func IANAToOpenSSL(name string) (string, bool) {
+	cipherNames.RLock()
+	defer cipherNames.RUnlock()
+
+	openssl, ok := cipherNames.ianaToOpenSSL[strings.ToUpper(name)]
+
+	return openssl, ok
+}
+
+// OpenSSLToIANA translates an OpenSSL cipher suite name (e.g., "ECDHE-RSA-AES128-GCM-SHA256") to its IANA
+// name (e.g., "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"). The lookup is case-insensitive.
+//
+// Parameters:
+//   - name: The OpenSSL cipher suite name.
+//
+// Returns:
+//   - The IANA name.
+//   - Whether the name is known.
+func OpenSSLToIANA(name string) (string, bool) {
+	cipherNames.RLock()
+	defer cipherNames.RUnlock()
+
+	iana, ok := cipherNames.openSSLToIANA[strings.ToUpper(name)]
+
+	return iana, ok
+}
+
+// Names returns every name the cipher suite is known by (IANA, OpenSSL, and GnuTLS), skipping empty and
+// duplicate names. This is convenient for matching against policies written in any naming scheme.
+//
+// Returns:
+//   - The distinct, non-empty names of the cipher suite.
+func (c CipherSuite) Names() []string {
+	names := make([]string, 0, 3)
+	for _, name := range []string{c.IANAName, c.OpenSSLName, c.GnuTLSName} {
+		if name != "" && !containsFold(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// containsFold reports whether `list` contains `s`, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRequestURLJoinsPaths(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		endpoint Endpoint
+		want     string
+	}{
+		{Endpoint{BaseURL: "https://host"}, "https://host/domain?url=example.com"},
+		{Endpoint{BaseURL: "https://host/"}, "https://host/domain?url=example.com"},
+		{Endpoint{BaseURL: "https://host/prefix"}, "https://host/prefix/domain?url=example.com"},
+		{Endpoint{BaseURL: "https://host/prefix/"}, "https://host/prefix/domain?url=example.com"},
+		{Endpoint{BaseURL: "https://host", PathPrefix: "/prefix/"}, "https://host/prefix/domain?url=example.com"},
+		{Endpoint{BaseURL: "https://host/", PathPrefix: "prefix"}, "https://host/prefix/domain?url=example.com"},
+	}
+
+	for _, tt := range tests {
+		c := NewClient(WithEndpoint(&tt.endpoint))
+
+		got, err := c.requestURL("/domain", &requestOptions{query: url.Values{"url": {"example.com"}}})
+		if err != nil {
+			t.Errorf("requestURL(%+v) error = %v", tt.endpoint, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("requestURL(%+v) = %q, want %q", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+func TestRequestsBehindPathPrefix(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Path
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	for _, base := range []string{srv.URL + "/devsec", srv.URL + "/devsec/"} {
+		c := NewClient(WithEndpoint(&Endpoint{BaseURL: base}))
+
+		if _, err := c.TLS(context.Background(), "example.com"); err != nil {
+			t.Fatalf("TLS() with base URL %q error = %v", base, err)
+		}
+		if got != "/devsec/tls" {
+			t.Errorf("TLS() with base URL %q requested %q, want /devsec/tls", base, got)
+		}
+	}
+}
+
+func TestResponseHeaderTimeout(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("url") == "hung.example.com" {
+			// Never send headers.
+			<-r.Context().Done()
+			return
+		}
+
+		// Send headers at once, then a slow body, which the header timeout must not cut off.
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClientWithConfig(&Config{
+		Endpoint:              &Endpoint{BaseURL: srv.URL},
+		Timeout:               5 * time.Second,
+		ResponseHeaderTimeout: 50 * time.Millisecond,
+	})
+
+	start := time.Now()
+	_, err := c.TLS(context.Background(), "hung.example.com")
+	if !errors.Is(err, ErrResponseHeaderTimeout) || !errors.Is(err, ErrTimeout) {
+		t.Errorf("TLS() of a hung API error = %v, want ErrResponseHeaderTimeout and ErrTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("TLS() of a hung API took %s, want it to fail fast", elapsed)
+	}
+
+	if _, err := c.TLS(context.Background(), "slow-body.example.com"); err != nil {
+		t.Errorf("TLS() with a slow body error = %v, want the body to be read", err)
+	}
+}
@@ -0,0 +1,86 @@
+package devsectools
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// ClientStats counts the requests made by a client since it was created or its stats were last reset.
+type ClientStats struct {
+	Requests    int64            // Number of API calls started.
+	Succeeded   int64            // Number of API calls which succeeded, including cache hits.
+	Failed      int64            // Number of API calls which failed.
+	Retries     int64            // Number of retried attempts.
+	CacheHits   int64            // Number of API calls answered from `Config.Cache`.
+	CacheMisses int64            // Number of API calls which looked in `Config.Cache` and missed.
+	ByStatus    map[string]int64 // Failures by status class: "4xx", "5xx", or "other" if there was no error status.
+}
+
+// Stats returns a snapshot of the client's request counters. The snapshot is consistent: it reflects every
+// counter at the same instant. It is safe to call concurrently with requests.
+//
+// Returns:
+//   - A copy of the client's statistics.
+func (c *Client) Stats() ClientStats {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	stats := c.stats
+	stats.ByStatus = maps.Clone(c.stats.ByStatus)
+
+	return stats
+}
+
+// ResetStats sets every request counter back to zero.
+func (c *Client) ResetStats() {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	c.stats = ClientStats{}
+}
+
+// record updates the request counters for an event.
+//
+// Parameters:
+//   - kind: The kind of event.
+//   - err: The error of a failed request, if any.
+func (c *Client) record(kind EventKind, err error) {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	switch kind {
+	case EventRequestStart:
+		c.stats.Requests++
+	case EventRequestSuccess:
+		c.stats.Succeeded++
+	case EventRequestFailure:
+		c.stats.Failed++
+		if c.stats.ByStatus == nil {
+			c.stats.ByStatus = make(map[string]int64)
+		}
+		c.stats.ByStatus[statusClass(err)]++
+	case EventRetry:
+		c.stats.Retries++
+	case EventCacheHit:
+		c.stats.CacheHits++
+	}
+}
+
+// recordCacheMiss counts a lookup in `Config.Cache` which found nothing.
+func (c *Client) recordCacheMiss() {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	c.stats.CacheMisses++
+}
+
+// statusClass classifies a failure by its HTTP status (e.g., "4xx"), or "other" if it has none.
+func statusClass(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode >= 100 && apiErr.StatusCode < 600 {
+		return fmt.Sprintf("%dxx", apiErr.StatusCode/100)
+	}
+
+	return "other"
+}
@@ -0,0 +1,48 @@
+package devsectools
+
+import "time"
+
+// clock abstracts the passage of time so that time-dependent behavior (rate limiting, timeouts, and
+// anything else that waits) can be driven deterministically in tests.
+type clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the default clock, backed by the `time` package.
+type realClock struct{}
+
+// Now returns the current local time.
+func (realClock) Now() time.Time { return time.Now() }
+
+// Sleep pauses the current goroutine for at least the duration `d`.
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// setClock replaces the clock used by the client, and by its `Config.Cache`, `Config.TokenProvider`, and
+// `Config.Signer` if they are a `MemoryCache`, a `CachingTokenProvider`, and an `HMACSigner`. It exists for
+// tests which need to control time, and must be called before the client is used.
+//
+// Parameters:
+//   - clk: The clock to use. A `nil` value restores the real clock.
+func (c *Client) setClock(clk clock) {
+	if clk == nil {
+		clk = realClock{}
+	}
+	c.clock = clk
+
+	if cache, ok := c.config.Cache.(*MemoryCache); ok {
+		cache.clock = clk
+	}
+	if provider, ok := c.config.TokenProvider.(*CachingTokenProvider); ok {
+		provider.mu.Lock()
+		provider.clock = clk
+		provider.mu.Unlock()
+	}
+	if signer, ok := c.config.Signer.(*HMACSigner); ok {
+		signer.clock = clk
+	}
+}
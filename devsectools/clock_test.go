@@ -0,0 +1,170 @@
+package devsectools
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock which only moves when told to. Waits complete immediately, advancing the clock by
+// their duration, and are recorded so that tests can check how long the code under test wanted to wait.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+// newFakeClock creates a fake clock set to a fixed time.
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// Now returns the fake time.
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+// Sleep records the wait and advances the clock by `d`.
+func (f *fakeClock) Sleep(d time.Duration) {
+	f.wait(d)
+}
+
+// After records the wait, advances the clock by `d`, and returns a channel which is already ready.
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- f.wait(d)
+
+	return ch
+}
+
+// Advance moves the clock forward by `d` without recording a wait.
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+}
+
+// Waits returns the duration of every wait so far, in order.
+func (f *fakeClock) Waits() []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.waits)
+}
+
+// wait records a wait of `d` and advances the clock past it.
+func (f *fakeClock) wait(d time.Duration) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.waits = append(f.waits, d)
+	f.now = f.now.Add(d)
+
+	return f.now
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	clk := newFakeClock()
+	limiter := newRateLimiter(clk, 10)
+
+	for range 3 {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatalf("wait() error = %v", err)
+		}
+	}
+
+	// The first operation starts at once; each of the others waits out the 100ms interval.
+	want := []time.Duration{100 * time.Millisecond, 100 * time.Millisecond}
+	if got := clk.Waits(); !slices.Equal(got, want) {
+		t.Errorf("waits = %v, want %v", got, want)
+	}
+
+	// Once the clock has moved past the schedule, the next operation starts at once.
+	clk.Advance(time.Second)
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+	if got := len(clk.Waits()); got != len(want) {
+		t.Errorf("wait() after an idle second waited; %d waits, want %d", got, len(want))
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	t.Parallel()
+
+	srv, sent := newVersionServer(t)
+	clk := newFakeClock()
+	c := NewClientWithConfig(&Config{
+		Endpoint: &Endpoint{BaseURL: srv.URL},
+		Timeout:  DefaultTimeout,
+		Cache:    NewMemoryCache(time.Minute),
+	})
+	c.setClock(clk)
+
+	tests := []struct {
+		advance time.Duration
+		want    string // The hostname returned.
+	}{
+		{0, "v1"},
+		{59 * time.Second, "v1"},
+		{time.Second, "v2"}, // Expired exactly at the TTL.
+		{30 * time.Second, "v2"},
+	}
+
+	for _, tt := range tests {
+		clk.Advance(tt.advance)
+
+		resp, err := c.Domain(context.Background(), "example.com")
+		if err != nil {
+			t.Fatalf("Domain() error = %v", err)
+		}
+		if resp.Hostname != tt.want {
+			t.Errorf("Domain() at %s = %q, want %q", clk.Now().Format(time.TimeOnly), resp.Hostname, tt.want)
+		}
+	}
+
+	if got := sent.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+}
+
+func TestSendBackoffUsesClock(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newStatusServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	clk := newFakeClock()
+	c := NewClientWithConfig(&Config{
+		Endpoint:    &Endpoint{BaseURL: srv.URL},
+		Timeout:     DefaultTimeout,
+		RetryPolicy: &RetryPolicy{MaxRetries: 3, BaseDelay: time.Minute, MaxDelay: time.Hour},
+	})
+	c.setClock(clk)
+
+	// With real time, these backoffs would take minutes.
+	if _, err := c.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("TLS() error = %v", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+
+	waits := clk.Waits()
+	if len(waits) != 2 {
+		t.Fatalf("waits = %v, want 2 backoffs", waits)
+	}
+	for attempt, wait := range waits {
+		full := time.Minute << attempt
+		if wait < full/2 || wait >= full {
+			t.Errorf("backoff %d = %s, want it in [%s, %s)", attempt, wait, full/2, full)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package devsectools
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// writeClientCert writes a self-signed client certificate and its key to PEM files in a temporary directory.
+func writeClientCert(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "devsectools-test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(certFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+// newTLSServer starts a TLS test server which answers every request with an empty JSON object.
+func newTLSServer(t *testing.T, clientAuth tls.ClientAuthType) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: clientAuth, MinVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// serverRoots returns a certificate pool which trusts the test server's certificate.
+func serverRoots(srv *httptest.Server) *x509.CertPool {
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+
+	return roots
+}
+
+func TestCloneAppliesTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	srv := newTLSServer(t, tls.NoClientCert)
+	parent := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+	t.Cleanup(func() { _ = parent.Close() })
+
+	if _, err := parent.TLS(context.Background(), "example.com"); err == nil {
+		t.Fatal("parent TLS() succeeded without trusting the server's certificate")
+	}
+
+	clone := parent.Clone(WithTLSConfig(&tls.Config{RootCAs: serverRoots(srv), MinVersion: tls.VersionTLS12}))
+	t.Cleanup(func() { _ = clone.Close() })
+
+	if _, err := clone.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("clone TLS() error = %v", err)
+	}
+}
+
+func TestCloneAppliesClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	srv := newTLSServer(t, tls.RequireAnyClientCert)
+	certFile, keyFile := writeClientCert(t)
+
+	parent := NewClient(
+		WithEndpoint(&Endpoint{BaseURL: srv.URL}),
+		WithTLSConfig(&tls.Config{RootCAs: serverRoots(srv), MinVersion: tls.VersionTLS12}),
+	)
+	t.Cleanup(func() { _ = parent.Close() })
+
+	if _, err := parent.TLS(context.Background(), "example.com"); err == nil {
+		t.Fatal("parent TLS() succeeded without a client certificate")
+	}
+
+	clone := parent.Clone(WithClientCertificate(certFile, keyFile))
+	t.Cleanup(func() { _ = clone.Close() })
+
+	if _, err := clone.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("clone TLS() error = %v", err)
+	}
+}
+
+func TestCloneKeepsCustomTransport(t *testing.T) {
+	t.Parallel()
+
+	transport := &http.Transport{}
+	parent := NewClient(WithTransport(transport))
+
+	clone := parent.Clone(WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS13}))
+	if clone.client().Transport != transport {
+		t.Error("Clone() replaced a transport supplied with WithTransport")
+	}
+}
+
+func TestCloneSharesTransport(t *testing.T) {
+	t.Parallel()
+
+	parent := NewClient()
+
+	clone := parent.Clone(WithTimeout(time.Second))
+	if clone.client().Transport != parent.client().Transport {
+		t.Error("Clone() without connection settings didn't share the parent's transport")
+	}
+}
+
+func TestCloneAppliesProxyURL(t *testing.T) {
+	t.Parallel()
+
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute URL of the API.
+		if r.URL.Host != "api.devsectools.test" {
+			http.Error(w, "unexpected host", http.StatusBadGateway)
+			return
+		}
+
+		proxied.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parent := NewClient(WithEndpoint(&Endpoint{BaseURL: "http://api.devsectools.test"}))
+	t.Cleanup(func() { _ = parent.Close() })
+
+	clone := parent.Clone(WithProxyURL(proxyURL))
+	t.Cleanup(func() { _ = clone.Close() })
+
+	if _, err := clone.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("clone TLS() error = %v", err)
+	}
+	if got := proxied.Load(); got != 1 {
+		t.Errorf("proxy received %d requests, want 1", got)
+	}
+	if parent.client().Transport == clone.client().Transport {
+		t.Error("Clone(WithProxyURL) shares the parent's transport")
+	}
+}
+
+func TestCloneAppliesRootCAs(t *testing.T) {
+	t.Parallel()
+
+	srv := newTLSServer(t, tls.NoClientCert)
+	parent := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+	t.Cleanup(func() { _ = parent.Close() })
+
+	if _, err := parent.TLS(context.Background(), "example.com"); err == nil {
+		t.Fatal("parent TLS() succeeded without trusting the server's certificate")
+	}
+
+	clone := parent.Clone(WithRootCAs(serverRoots(srv)))
+	t.Cleanup(func() { _ = clone.Close() })
+
+	if _, err := clone.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("clone TLS() error = %v", err)
+	}
+}
+
+func TestCloneAppliesInsecureSkipVerify(t *testing.T) {
+	t.Parallel()
+
+	srv := newTLSServer(t, tls.NoClientCert)
+	parent := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+	t.Cleanup(func() { _ = parent.Close() })
+
+	clone := parent.Clone(WithInsecureSkipVerify())
+	t.Cleanup(func() { _ = clone.Close() })
+
+	if _, err := clone.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("clone TLS() error = %v", err)
+	}
+	if _, err := parent.TLS(context.Background(), "example.com"); err == nil {
+		t.Error("parent TLS() succeeded after its clone disabled verification")
+	}
+}
+
+// newRedirectServer starts a test server which redirects every path once, to the same path with "/done"
+// appended, and answers that.
+func newRedirectServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/done") {
+			http.Redirect(w, r, r.URL.Path+"/done?"+r.URL.RawQuery, http.StatusFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestCloneUsesOwnRedirectPolicy(t *testing.T) {
+	t.Parallel()
+
+	srv := newRedirectServer(t)
+
+	var parentHops, cloneHops atomic.Int32
+	parent := NewClientWithConfig(&Config{
+		Endpoint:   &Endpoint{BaseURL: srv.URL},
+		Timeout:    DefaultTimeout,
+		OnRedirect: func(*url.URL, *url.URL, int) { parentHops.Add(1) },
+	})
+
+	clone := parent.Clone(func(o *clientOptions) {
+		o.config.OnRedirect = func(*url.URL, *url.URL, int) { cloneHops.Add(1) }
+	})
+	if _, err := clone.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("clone TLS() error = %v", err)
+	}
+	if parentHops.Load() != 0 || cloneHops.Load() != 1 {
+		t.Errorf("redirect reported %d times to the parent and %d to the clone, want 0 and 1",
+			parentHops.Load(), cloneHops.Load())
+	}
+
+	strict := parent.Clone(func(o *clientOptions) { o.config.MaxRedirects = -1 })
+	if _, err := strict.TLS(context.Background(), "example.com"); !errors.Is(err, ErrTooManyRedirects) {
+		t.Errorf("clone TLS() with redirects disabled error = %v, want ErrTooManyRedirects", err)
+	}
+	if _, err := parent.TLS(context.Background(), "example.com"); err != nil {
+		t.Errorf("parent TLS() error = %v after its clone disabled redirects", err)
+	}
+}
+
+func TestCloneKeepsCustomRedirectPolicy(t *testing.T) {
+	t.Parallel()
+
+	srv := newRedirectServer(t)
+
+	var hops atomic.Int32
+	parent := NewClient(
+		WithEndpoint(&Endpoint{BaseURL: srv.URL}),
+		WithHTTPClient(&http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+			hops.Add(1)
+			return nil
+		}}),
+	)
+
+	clone := parent.Clone(WithTimeout(time.Minute))
+	if _, err := clone.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("clone TLS() error = %v", err)
+	}
+	if got := hops.Load(); got != 1 {
+		t.Errorf("custom CheckRedirect called %d times, want 1", got)
+	}
+}
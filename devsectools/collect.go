@@ -0,0 +1,70 @@
+package devsectools
+
+import "fmt"
+
+// CollectDomain extracts the typed results of all "domain" requests from a completed batch. It is meant to
+// be called after `Batch` (or `BatchWithOptions`) returns.
+//
+// Parameters:
+//   - requests: The slice of `BatchRequest` structs that was passed to `Batch`.
+//
+// Returns:
+//   - A map of URL to `DomainResponse` for every successful request.
+//   - A map of URL to error for every request that failed or whose result has an unexpected type.
+func CollectDomain(requests []BatchRequest) (map[string]*DomainResponse, map[string]error) {
+	return collect[DomainResponse](requests, MethodDomain)
+}
+
+// CollectHTTP extracts the typed results of all "http" requests from a completed batch. It is meant to be
+// called after `Batch` (or `BatchWithOptions`) returns.
+//
+// Parameters:
+//   - requests: The slice of `BatchRequest` structs that was passed to `Batch`.
+//
+// Returns:
+//   - A map of URL to `HttpResponse` for every successful request.
+//   - A map of URL to error for every request that failed or whose result has an unexpected type.
+func CollectHTTP(requests []BatchRequest) (map[string]*HttpResponse, map[string]error) {
+	return collect[HttpResponse](requests, MethodHTTP)
+}
+
+// CollectTLS extracts the typed results of all "tls" requests from a completed batch. It is meant to be
+// called after `Batch` (or `BatchWithOptions`) returns.
+//
+// Parameters:
+//   - requests: The slice of `BatchRequest` structs that was passed to `Batch`.
+//
+// Returns:
+//   - A map of URL to `TlsResponse` for every successful request.
+//   - A map of URL to error for every request that failed or whose result has an unexpected type.
+func CollectTLS(requests []BatchRequest) (map[string]*TlsResponse, map[string]error) {
+	return collect[TlsResponse](requests, MethodTLS)
+}
+
+// collect filters a completed batch by method and type-asserts each result to `*T`.
+func collect[T any](requests []BatchRequest, method ScanMethod) (map[string]*T, map[string]error) {
+	results := make(map[string]*T)
+	errs := make(map[string]error)
+
+	for i := range requests {
+		req := &requests[i]
+		if req.Method.canonical() != method {
+			continue
+		}
+
+		if req.Err != nil {
+			errs[req.URL] = req.Err
+			continue
+		}
+
+		result, ok := req.Result.(*T)
+		if !ok || result == nil {
+			errs[req.URL] = fmt.Errorf("batch result for %q has type %T, want %T", req.URL, req.Result, result)
+			continue
+		}
+
+		results[req.URL] = result
+	}
+
+	return results, errs
+}
@@ -0,0 +1,87 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// WithConnectIP sends the request to a specific IP address instead of resolving the API's host name, for
+// diagnosing one node of a load-balanced API deployment. The URL, `Host` header, and TLS server name (SNI)
+// still use the configured host name, so certificate verification is unaffected.
+//
+// This only affects the SDK's connection to the API. The API's own connection to the scanned host is
+// unchanged.
+//
+// A dedicated connection is made for each request with this option; connections aren't reused. Like
+// `WithForceRefresh`, it skips reading `Config.Cache`, so the response comes from the chosen node. Any
+// configured proxy is bypassed, since it would connect to the host name rather than the chosen address.
+//
+// Parameters:
+//   - ip: The IPv4 or IPv6 address to connect to (e.g., "203.0.113.10"), without a port.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithConnectIP(ip string) RequestOption {
+	return func(o *requestOptions) {
+		o.connectIP = ip
+		o.forceRefresh = true
+	}
+}
+
+// httpClientFor returns the HTTP client to send a request with. Requests with `WithConnectIP` get a
+// one-shot client whose transport dials the given address; all others share the client's own transport.
+// Requests with `WithRequestTimeout` get a copy of the client with that timeout.
+//
+// Parameters:
+//   - ro: The collected per-call settings.
+//
+// Returns:
+//   - The HTTP client.
+//   - A function which releases the client's resources. It must be called once the response is read.
+//   - An error if the connect IP is invalid or the transport can't be customized.
+func (c *Client) httpClientFor(ro *requestOptions) (*http.Client, func(), error) {
+	httpClient := c.client()
+	if ro.timeout > 0 {
+		withTimeout := *httpClient
+		withTimeout.Timeout = ro.timeout
+		httpClient = &withTimeout
+	}
+
+	if ro.connectIP == "" {
+		return httpClient, func() {}, nil
+	}
+
+	ip := net.ParseIP(ro.connectIP)
+	if ip == nil {
+		return nil, nil, fmt.Errorf("devsectools: invalid connect IP %q", ro.connectIP)
+	}
+
+	base, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		return nil, nil, errors.New("devsectools: WithConnectIP requires an *http.Transport")
+	}
+
+	// The connection must go to the chosen node, not to a proxy which would resolve the host name itself.
+	transport := base.Clone()
+	transport.Proxy = nil
+	dial := transport.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return dial(ctx, network, net.JoinHostPort(ip.String(), port))
+	}
+
+	client := *httpClient
+	client.Transport = transport
+
+	return &client, transport.CloseIdleConnections, nil
+}
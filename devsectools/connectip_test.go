@@ -0,0 +1,53 @@
+package devsectools
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestConnectIPBypassesProxy(t *testing.T) {
+	t.Parallel()
+
+	var host string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		_, _ = w.Write([]byte(`{"hostname": "example.com"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Neither the API's host name nor the proxy resolve, so the request only succeeds if it's dialed
+	// directly to the chosen address.
+	c := NewClient(
+		WithEndpoint(&Endpoint{BaseURL: "http://api.devsectools.invalid:" + port}),
+		WithProxyURL(&url.URL{Scheme: "http", Host: "proxy.devsectools.invalid:3128"}),
+	)
+
+	resp, err := c.Domain(context.Background(), "example.com", WithConnectIP("127.0.0.1"))
+	if err != nil {
+		t.Fatalf("Domain() error = %v", err)
+	}
+	if resp.Hostname != "example.com" {
+		t.Errorf("Domain() = %q, want example.com", resp.Hostname)
+	}
+	if want := "api.devsectools.invalid:" + port; host != want {
+		t.Errorf("Host header = %q, want %q", host, want)
+	}
+}
+
+func TestConnectIPInvalid(t *testing.T) {
+	t.Parallel()
+
+	c := NewClient(WithEndpoint(&Endpoint{BaseURL: "http://api.devsectools.invalid"}))
+	if _, err := c.Domain(context.Background(), "example.com", WithConnectIP("not-an-ip")); err == nil {
+		t.Error("Domain() error = nil, want an error for an invalid connect IP")
+	}
+}
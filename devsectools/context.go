@@ -0,0 +1,126 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// baggageKey is the context key for W3C baggage.
+type baggageKey struct{}
+
+// WithBaggage returns a context carrying W3C baggage entries, which are sent in the `baggage` header of
+// every request made with it (https://www.w3.org/TR/baggage/). Entries are merged with any baggage already
+// in the context, replacing entries with the same key. Contexts without baggage add no header and no cost.
+//
+// A `baggage` header set explicitly with `Config.DefaultHeaders` or `WithHeader` takes precedence.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - baggage: The entries to add. Values are percent-encoded when sent.
+//
+// Returns:
+//   - A derived context carrying the baggage.
+func WithBaggage(ctx context.Context, baggage map[string]string) context.Context {
+	merged := maps.Clone(baggageFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(baggage))
+	}
+	maps.Copy(merged, baggage)
+
+	return context.WithValue(ctx, baggageKey{}, merged)
+}
+
+// baggageFromContext returns the baggage entries carried by the context, if any.
+func baggageFromContext(ctx context.Context) map[string]string {
+	baggage, _ := ctx.Value(baggageKey{}).(map[string]string)
+
+	return baggage
+}
+
+// encodeBaggage formats baggage entries as a W3C `baggage` header value, sorted by key for stable output.
+//
+// Parameters:
+//   - baggage: The entries to encode.
+//
+// Returns:
+//   - The header value (e.g., "tenant=acme,region=eu%20west").
+func encodeBaggage(baggage map[string]string) string {
+	members := make([]string, 0, len(baggage))
+	for _, key := range slices.Sorted(maps.Keys(baggage)) {
+		members = append(members, key+"="+url.PathEscape(baggage[key]))
+	}
+
+	return strings.Join(members, ",")
+}
+
+// contextErr returns the error of a finished context, including its cause. See `withCause`.
+//
+// Parameters:
+//   - ctx: The context.
+//
+// Returns:
+//   - `nil` if the context isn't done, otherwise its error.
+func contextErr(ctx context.Context) error {
+	return withCause(ctx, ctx.Err())
+}
+
+// withCause adds the cause of a finished context (e.g., `ErrBatchDeadline`) to an error, so that callers
+// can tell which of several deadlines ended a request. Errors which already carry the cause, and contexts
+// with no cause beyond their error, are returned unchanged.
+//
+// Parameters:
+//   - ctx: The context the failed operation ran with.
+//   - err: The error of the operation.
+//
+// Returns:
+//   - The error, wrapped with the context's cause if appropriate.
+func withCause(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	cause := context.Cause(ctx)
+	if cause == nil || cause == ctx.Err() || errors.Is(err, cause) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", cause, err)
+}
+
+// requestContext derives the context for one request of a batch. The result carries the values of
+// `reqCtx`, and is cancelled when either `reqCtx` or `batchCtx` is done, with the earlier of their
+// deadlines.
+//
+// Parameters:
+//   - batchCtx: The context of the whole batch.
+//   - reqCtx: The request's own context. May be `nil`, in which case `batchCtx` is used unchanged.
+//
+// Returns:
+//   - The context to use for the request.
+//   - A function which releases the resources of the derived context. It must be called.
+func requestContext(batchCtx, reqCtx context.Context) (context.Context, context.CancelFunc) {
+	if reqCtx == nil {
+		return batchCtx, func() {}
+	}
+
+	ctx, cancelCause := context.WithCancelCause(reqCtx)
+	stop := context.AfterFunc(batchCtx, func() {
+		cancelCause(context.Cause(batchCtx))
+	})
+
+	cancelDeadline := context.CancelFunc(func() {})
+	if deadline, ok := batchCtx.Deadline(); ok {
+		ctx, cancelDeadline = context.WithDeadline(ctx, deadline)
+	}
+
+	return ctx, func() {
+		cancelDeadline()
+		stop()
+		cancelCause(context.Canceled)
+	}
+}
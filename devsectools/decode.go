@@ -0,0 +1,105 @@
+package devsectools
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxErrorBodyLen bounds how much of a non-JSON error body is used as the error message.
+const maxErrorBodyLen = 512
+
+// decodeError converts an error response into an error, using `Config.ErrorDecoder` if configured.
+//
+// Parameters:
+//   - body: The response body.
+//   - statusCode: The HTTP status code.
+//
+// Returns:
+//   - The decoded error. Never `nil`.
+func (c *Client) decodeError(body []byte, statusCode int) error {
+	if c.config.ErrorDecoder != nil {
+		if err := c.config.ErrorDecoder(body, statusCode); err != nil {
+			return err
+		}
+	}
+
+	return &APIError{StatusCode: statusCode, Message: errorMessage(body)}
+}
+
+// errorMessage extracts a human-readable message from an error response body. It understands the API's
+// `{"error": "..."}` envelope as well as the `{"message": "..."}` and `{"errors": [...]}` shapes used by some
+// API versions and proxies, and otherwise falls back to the (truncated) raw body.
+//
+// Parameters:
+//   - body: The response body.
+//
+// Returns:
+//   - The error message, or an empty string if the body is empty.
+func errorMessage(body []byte) string {
+	var envelope struct {
+		Error   json.RawMessage `json:"error"`
+		Message json.RawMessage `json:"message"`
+		Errors  json.RawMessage `json:"errors"`
+	}
+
+	if json.Unmarshal(body, &envelope) == nil {
+		for _, field := range []json.RawMessage{envelope.Error, envelope.Message, envelope.Errors} {
+			if msg := rawMessageText(field); msg != "" {
+				return msg
+			}
+		}
+	}
+
+	msg := strings.TrimSpace(string(body))
+	if len(msg) > maxErrorBodyLen {
+		// Cut at the start of a character, so that a multi-byte character isn't split.
+		end := maxErrorBodyLen
+		for end > 0 && !utf8.RuneStart(msg[end]) {
+			end--
+		}
+		msg = msg[:end] + "…"
+	}
+
+	return msg
+}
+
+// rawMessageText extracts text from a JSON value which may be a string, an object with a "message" field,
+// or an array of either.
+//
+// Parameters:
+//   - raw: The JSON value. May be empty.
+//
+// Returns:
+//   - The text, with array elements joined by "; ". Empty if no text was found.
+func rawMessageText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var str string
+	if json.Unmarshal(raw, &str) == nil {
+		return str
+	}
+
+	var obj struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(raw, &obj) == nil && obj.Message != "" {
+		return obj.Message
+	}
+
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		msgs := make([]string, 0, len(list))
+		for _, item := range list {
+			if msg := rawMessageText(item); msg != "" {
+				msgs = append(msgs, msg)
+			}
+		}
+
+		return strings.Join(msgs, "; ")
+	}
+
+	return ""
+}
@@ -0,0 +1,119 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newErrorServer starts a test server which answers every request with the given status and body.
+func newErrorServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestErrorResponseShapes(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		body string
+		want string
+	}{
+		"error":          {`{"error": "invalid host"}`, "invalid host"},
+		"message":        {`{"message": "invalid host"}`, "invalid host"},
+		"errors strings": {`{"errors": ["invalid host", "bad port"]}`, "invalid host; bad port"},
+		"errors objects": {
+			`{"errors": [{"message": "invalid host"}, {"message": "bad port"}]}`,
+			"invalid host; bad port",
+		},
+		"error object":      {`{"error": {"message": "invalid host"}}`, "invalid host"},
+		"error before msg":  {`{"message": "second", "error": "first"}`, "first"},
+		"empty error field": {`{"error": "", "message": "invalid host"}`, "invalid host"},
+		"raw text":          {"upstream unavailable\n", "upstream unavailable"},
+		"html":              {"<html>Bad Gateway</html>", "<html>Bad Gateway</html>"},
+		"long raw text":     {strings.Repeat("x", maxErrorBodyLen+10), strings.Repeat("x", maxErrorBodyLen) + "…"},
+		"long multi-byte": {
+			"x" + strings.Repeat("é", maxErrorBodyLen), // Byte maxErrorBodyLen falls inside an "é".
+			"x" + strings.Repeat("é", maxErrorBodyLen/2-1) + "…",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newErrorServer(t, http.StatusBadRequest, tt.body)
+			c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+
+			_, err := c.TLS(context.Background(), "example.com")
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("TLS() error = %v, want an *APIError", err)
+			}
+			if apiErr.StatusCode != http.StatusBadRequest || apiErr.Message != tt.want {
+				t.Errorf("APIError = {%d, %q}, want {400, %q}", apiErr.StatusCode, apiErr.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorResponseWithoutBody(t *testing.T) {
+	t.Parallel()
+
+	srv := newErrorServer(t, http.StatusServiceUnavailable, "")
+	c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+
+	_, err := c.TLS(context.Background(), "example.com")
+	if err == nil || err.Error() != "devsectools: API returned status 503" {
+		t.Errorf("TLS() error = %v, want the status code", err)
+	}
+}
+
+func TestErrorDecoder(t *testing.T) {
+	t.Parallel()
+
+	errQuota := errors.New("quota exceeded")
+	decoder := func(body []byte, statusCode int) error {
+		if statusCode == http.StatusTooManyRequests {
+			return fmt.Errorf("%w: %s", errQuota, body)
+		}
+
+		return nil // Use the built-in decoding.
+	}
+
+	tests := []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusTooManyRequests, func(err error) bool { return errors.Is(err, errQuota) }},
+		{http.StatusBadRequest, func(err error) bool {
+			var apiErr *APIError
+			return errors.As(err, &apiErr) && apiErr.Message == "plan limit"
+		}},
+	}
+
+	for _, tt := range tests {
+		srv := newErrorServer(t, tt.status, `{"message": "plan limit"}`)
+		c := NewClientWithConfig(&Config{
+			Endpoint:     &Endpoint{BaseURL: srv.URL},
+			Timeout:      DefaultTimeout,
+			ErrorDecoder: decoder,
+		})
+
+		if _, err := c.TLS(context.Background(), "example.com"); !tt.check(err) {
+			t.Errorf("TLS() with status %d error = %v", tt.status, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package devsectools
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	defaultClientOnce sync.Once
+	defaultClientMu   sync.RWMutex
+	defaultClient     *Client
+)
+
+// DefaultClient returns the package-level client used by the convenience functions (`Domain`, `HTTP`, and
+// `TLS`). It is created with `NewClient` on first use unless `SetDefaultClient` was called first.
+//
+// Returns:
+//   - A pointer to the default Client.
+func DefaultClient() *Client {
+	defaultClientOnce.Do(func() {
+		defaultClientMu.Lock()
+		defer defaultClientMu.Unlock()
+
+		if defaultClient == nil {
+			defaultClient = NewClient()
+		}
+	})
+
+	defaultClientMu.RLock()
+	defer defaultClientMu.RUnlock()
+
+	return defaultClient
+}
+
+// SetDefaultClient replaces the package-level client used by the convenience functions. It is safe to call
+// concurrently with them; calls already in progress finish on the client they started with.
+//
+// Parameters:
+//   - client: A pointer to the Client to use. Passing `nil` restores a client created with `NewClient`.
+func SetDefaultClient(client *Client) {
+	if client == nil {
+		client = NewClient()
+	}
+
+	defaultClientMu.Lock()
+	defer defaultClientMu.Unlock()
+
+	defaultClient = client
+}
+
+// Domain retrieves the parsed domain information from the API using the default client.
+//
+// Parameters:
+//   - ctx: Context for handling timeouts and cancellations.
+//   - url: The domain to scan (e.g., "example.com").
+//   - opts: Optional RequestOptions which customize this call.
+//
+// Returns:
+//   - A pointer to a `DomainResponse` struct containing the parsed hostname.
+//   - An error if the request fails.
+func Domain(ctx context.Context, url string, opts ...RequestOption) (*DomainResponse, error) {
+	return DefaultClient().Domain(ctx, url, opts...)
+}
+
+// HTTP retrieves HTTP protocol support information from the API using the default client.
+//
+// Parameters:
+//   - ctx: Context for handling timeouts and cancellations.
+//   - url: The domain to scan (e.g., "example.com").
+//   - opts: Optional RequestOptions which customize this call.
+//
+// Returns:
+//   - A pointer to a `HttpResponse` struct containing HTTP version support details.
+//   - An error if the request fails.
+func HTTP(ctx context.Context, url string, opts ...RequestOption) (*HttpResponse, error) {
+	return DefaultClient().HTTP(ctx, url, opts...)
+}
+
+// TLS retrieves TLS protocol support information from the API using the default client.
+//
+// Parameters:
+//   - ctx: Context for handling timeouts and cancellations.
+//   - url: The domain to scan (e.g., "example.com").
+//   - opts: Optional RequestOptions which customize this call.
+//
+// Returns:
+//   - A pointer to a `TlsResponse` struct containing TLS version support details and cipher suites.
+//   - An error if the request fails.
+func TLS(ctx context.Context, url string, opts ...RequestOption) (*TlsResponse, error) {
+	return DefaultClient().TLS(ctx, url, opts...)
+}
@@ -0,0 +1,77 @@
+package devsectools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// The tests in this file replace the package-level client, so they don't run in parallel.
+
+func TestDefaultClientConcurrentSwap(t *testing.T) {
+	previous := DefaultClient()
+	t.Cleanup(func() { SetDefaultClient(previous) })
+
+	var served [2]atomic.Int32
+	clients := make([]*Client, len(served))
+	for i := range served {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			served[i].Add(1)
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		t.Cleanup(srv.Close)
+
+		clients[i] = NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+	}
+	SetDefaultClient(clients[0])
+
+	const callers, calls = 8, 25
+
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for range calls {
+				if _, err := TLS(context.Background(), "example.com"); err != nil {
+					t.Errorf("TLS() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for i := range callers * calls {
+			SetDefaultClient(clients[i%len(clients)])
+		}
+	}()
+	wg.Wait()
+
+	if total := served[0].Load() + served[1].Load(); total != callers*calls {
+		t.Errorf("servers received %d requests, want %d", total, callers*calls)
+	}
+}
+
+func TestSetDefaultClientNil(t *testing.T) {
+	previous := DefaultClient()
+	t.Cleanup(func() { SetDefaultClient(previous) })
+
+	custom := NewClient(WithEndpoint(&LOCALDEV))
+	SetDefaultClient(custom)
+	if DefaultClient() != custom {
+		t.Fatal("DefaultClient() didn't return the client passed to SetDefaultClient")
+	}
+
+	SetDefaultClient(nil)
+	if c := DefaultClient(); c == nil || c == custom || !c.endpoint().Equal(PRODUCTION) {
+		t.Error("SetDefaultClient(nil) didn't restore a default client")
+	}
+}
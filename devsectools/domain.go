@@ -0,0 +1,82 @@
+package devsectools
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// IsValid reports whether the hostname looks like a resolvable DNS name: at most 253 characters, with at
+// least two dot-separated labels of 1–63 letters, digits, and hyphens, none starting or ending with a
+// hyphen. A single trailing dot is allowed. IP addresses are not host names, so they are not valid.
+//
+// Returns:
+//   - `true` if the hostname is a syntactically valid DNS name.
+func (r *DomainResponse) IsValid() bool {
+	if r == nil {
+		return false
+	}
+
+	host := strings.TrimSuffix(r.Hostname, ".")
+	if host == "" || len(host) > 253 || net.ParseIP(host) != nil {
+		return false
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	for _, label := range labels {
+		if !isValidLabel(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isValidLabel reports whether a DNS label is 1–63 letters, digits, and hyphens, not starting or ending
+// with a hyphen. Internationalized labels must already be in their ASCII ("xn--") form.
+func isValidLabel(label string) bool {
+	if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+
+	for _, ch := range label {
+		if !('a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || '0' <= ch && ch <= '9' || ch == '-') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RegistrableDomain returns the hostname's registrable domain, the public suffix plus one label (eTLD+1),
+// using the public suffix list. For example, "www.example.co.uk" becomes "example.co.uk". It is useful for
+// grouping scans by organization.
+//
+// Returns:
+//   - The lowercase registrable domain.
+//   - An error if the hostname is invalid, is an IP address, or is itself a public suffix (e.g., "co.uk").
+func (r *DomainResponse) RegistrableDomain() (string, error) {
+	if !r.IsValid() {
+		hostname := ""
+		if r != nil {
+			hostname = r.Hostname
+		}
+
+		return "", fmt.Errorf("%w: %q is not a valid host name", ErrInvalidURL, hostname)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(r.Hostname, "."))
+
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return "", fmt.Errorf("devsectools: no registrable domain for %q: %w", host, err)
+	}
+
+	return domain, nil
+}
@@ -0,0 +1,113 @@
+package devsectools
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Environment variables read by `NewClientFromEnv`.
+const (
+	EnvEndpoint = "DEVSECTOOLS_ENDPOINT" // "production", "localdev", or the base URL of a custom endpoint.
+	EnvTimeout  = "DEVSECTOOLS_TIMEOUT"  // A duration, as accepted by `time.ParseDuration` (e.g., "10s").
+	EnvAPIKey   = "DEVSECTOOLS_API_KEY"  // A bearer token sent with every request.
+)
+
+// NewClientFromEnv initializes a new API client configured from environment variables, so that the SDK can
+// be configured in containers and CI without code changes. Unset or empty variables keep the defaults of
+// `NewClient`:
+//
+//   - `DEVSECTOOLS_ENDPOINT`: "production", "localdev", or the base URL of a custom endpoint.
+//   - `DEVSECTOOLS_TIMEOUT`: the network timeout, as a duration (e.g., "10s").
+//   - `DEVSECTOOLS_API_KEY`: a bearer token, sent in the `Authorization` header of every request.
+//
+// Proxies are configured with the standard `HTTPS_PROXY`, `HTTP_PROXY`, and `NO_PROXY` variables, which
+// the SDK's transport honors by default.
+//
+// Parameters:
+//   - opts: Optional Options, which take precedence over the environment.
+//
+// Returns:
+//   - A pointer to the newly created Client.
+//   - An error naming the variable if any is invalid.
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	envOpts, err := optionsFromEnv(os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(append(envOpts, opts...)...), nil
+}
+
+// optionsFromEnv converts the environment variables read by `NewClientFromEnv` into Options.
+//
+// Parameters:
+//   - getenv: Looks up an environment variable (e.g., `os.Getenv`).
+//
+// Returns:
+//   - The Options for the variables which are set.
+//   - An error naming the variable if any is invalid.
+func optionsFromEnv(getenv func(string) string) ([]Option, error) {
+	var opts []Option
+
+	if value := strings.TrimSpace(getenv(EnvEndpoint)); value != "" {
+		endpoint, err := parseEndpoint(value)
+		if err != nil {
+			return nil, fmt.Errorf("devsectools: invalid %s: %w", EnvEndpoint, err)
+		}
+		opts = append(opts, WithEndpoint(endpoint))
+	}
+
+	if value := strings.TrimSpace(getenv(EnvTimeout)); value != "" {
+		timeout, err := parseTimeout(value)
+		if err != nil {
+			return nil, fmt.Errorf("devsectools: invalid %s: %w", EnvTimeout, err)
+		}
+		opts = append(opts, WithTimeout(timeout))
+	}
+
+	if value := strings.TrimSpace(getenv(EnvAPIKey)); value != "" {
+		opts = append(opts, WithAPIKey(value))
+	}
+
+	return opts, nil
+}
+
+// parseTimeout parses a positive duration (e.g., "10s").
+func parseTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("%q is not positive", value)
+	}
+
+	return timeout, nil
+}
+
+// parseEndpoint resolves the name of a built-in endpoint, or the base URL of a custom one.
+//
+// Parameters:
+//   - value: "production", "localdev" (in any case), or an absolute HTTP or HTTPS URL.
+//
+// Returns:
+//   - A pointer to the endpoint.
+//   - An error matching `ErrInvalidURL` if `value` is neither.
+func parseEndpoint(value string) (*Endpoint, error) {
+	switch strings.ToLower(value) {
+	case "production":
+		return &PRODUCTION, nil
+	case "localdev":
+		return &LOCALDEV, nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: %q is not a built-in endpoint or an HTTP(S) URL", ErrInvalidURL, value)
+	}
+
+	return &Endpoint{BaseURL: value}, nil
+}
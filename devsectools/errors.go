@@ -0,0 +1,147 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+)
+
+// Sentinel errors returned by the client. Use `errors.Is` to test for them, as they are usually wrapped.
+var (
+	// ErrTooManyRedirects is returned when the API redirects more times than `Config.MaxRedirects` allows.
+	ErrTooManyRedirects = errors.New("devsectools: too many redirects")
+
+	// ErrPanic is set on a `BatchRequest` whose goroutine panicked. The message includes the recovered value.
+	ErrPanic = errors.New("devsectools: panic during request")
+
+	// ErrResponseTooLarge is matched by a `*ResponseTooLargeError` when a response body exceeds its limit.
+	ErrResponseTooLarge = errors.New("devsectools: response too large")
+
+	// ErrBatchRunnerClosed is returned when work is submitted to a `BatchRunner` after `Close`.
+	ErrBatchRunnerClosed = errors.New("devsectools: batch runner closed")
+
+	// ErrClientClosed is returned by calls made after `Client.Close`.
+	ErrClientClosed = errors.New("devsectools: client closed")
+
+	// ErrTimeout is matched by errors caused by the client timeout or a context deadline. The original
+	// error is also wrapped, so `errors.Is(err, context.DeadlineExceeded)` continues to work.
+	ErrTimeout = errors.New("devsectools: timeout")
+
+	// ErrOffline is returned when a call requires network access but `Config.Offline` is set.
+	ErrOffline = errors.New("devsectools: offline")
+
+	// ErrOfflineMiss is returned when `Config.Offline` is set and the cache has no response for a request.
+	ErrOfflineMiss = errors.New("devsectools: offline and no cached response")
+
+	// ErrInsufficientTime is returned when the request context has less time left than
+	// `Config.MinRequestTime`.
+	ErrInsufficientTime = errors.New("devsectools: insufficient time left to send request")
+
+	// ErrUnexpectedStatus is returned when the API responds with a status below 400 that isn't listed in
+	// `Config.SuccessStatuses`.
+	ErrUnexpectedStatus = errors.New("devsectools: unexpected response status")
+
+	// ErrInvalidURL is returned when a scan target can't be converted into a form the API accepts, such as
+	// a host which isn't a valid internationalized domain name.
+	ErrInvalidURL = errors.New("devsectools: invalid URL")
+
+	// ErrConflictingAuth is returned when a request is configured with more than one of Basic credentials,
+	// an `Authenticator`, and a `TokenProvider`, since only one `Authorization` header can be sent.
+	ErrConflictingAuth = errors.New("devsectools: more than one kind of authentication is configured")
+
+	// ErrResponseHeaderTimeout is returned when the API accepts a request but doesn't start responding
+	// within `Config.ResponseHeaderTimeout`.
+	ErrResponseHeaderTimeout = errors.New("devsectools: timed out awaiting response headers")
+
+	// ErrRequestTimeout is the context cause when a request exceeds `Config.Timeout`. Errors caused by it
+	// match both it and `ErrTimeout`.
+	ErrRequestTimeout = errors.New("devsectools: per-request timeout exceeded")
+
+	// ErrBatchDeadline is the context cause when a batch exceeds `BatchOptions.OverallDeadline`. Requests
+	// which didn't complete in time have errors matching it.
+	ErrBatchDeadline = errors.New("devsectools: batch overall deadline exceeded")
+
+	// ErrInvalidMethod is returned for a batch request whose method isn't a known `ScanMethod`. The error
+	// also names the method and the index of the request.
+	ErrInvalidMethod = errors.New("devsectools: invalid batch request method")
+
+	// ErrProfileNotFound is returned when the config file has no profile with the requested name.
+	ErrProfileNotFound = errors.New("devsectools: profile not found")
+)
+
+// ResponseTooLargeError is returned when a response body exceeds `Config.MaxResponseBytes` or the limit set
+// with `WithMaxResponseBytes`. It matches `ErrResponseTooLarge` with `errors.Is`.
+type ResponseTooLargeError struct {
+	Limit int64 // The limit, in bytes, that was exceeded.
+}
+
+// Error implements the error interface.
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("%s: exceeds limit of %d bytes", ErrResponseTooLarge, e.Limit)
+}
+
+// Is reports whether the error matches `ErrResponseTooLarge`.
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
+
+// APIError is returned when the API responds with an error status.
+type APIError struct {
+	StatusCode int    // The HTTP status code of the response.
+	Message    string // The error message reported by the API, if any.
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("devsectools: API returned status %d", e.StatusCode)
+}
+
+// classifyError wraps timeouts with `ErrTimeout` so that callers can distinguish them from other failures.
+//
+// Parameters:
+//   - err: The error to classify. May be `nil`.
+//
+// Returns:
+//   - The classified error, or `err` unchanged if it isn't a timeout.
+func classifyError(err error) error {
+	if err == nil || errors.Is(err, ErrTimeout) {
+		return err
+	}
+
+	// `net/http` may report the cause of a cancelled request context, such as `ErrRequestTimeout`, in place
+	// of `context.DeadlineExceeded`.
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrRequestTimeout) ||
+		errors.Is(err, ErrBatchDeadline) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+
+	return err
+}
+
+// HostErrors maps each host (as passed to the SDK) to the error encountered while scanning it. It is
+// returned by the multi-host reporting methods so that failed hosts are never mistaken for clean ones.
+type HostErrors map[string]error
+
+// Error implements the error interface, listing every host in sorted order.
+func (e HostErrors) Error() string {
+	hosts := make([]string, 0, len(e))
+	for host := range e {
+		hosts = append(hosts, host)
+	}
+	slices.Sort(hosts)
+
+	parts := make([]string, len(hosts))
+	for i, host := range hosts {
+		parts[i] = host + ": " + e[host].Error()
+	}
+
+	return "devsectools: scan failed for " + strings.Join(parts, "; ")
+}
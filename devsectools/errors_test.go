@@ -0,0 +1,102 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newSleepyServer starts a test server which waits `delay` before answering, or until the request is
+// cancelled.
+func newSleepyServer(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestTimeoutErrors(t *testing.T) {
+	t.Parallel()
+
+	srv := newSleepyServer(t, 5*time.Second)
+
+	t.Run("client timeout", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}), WithTimeout(50*time.Millisecond))
+
+		_, err := c.TLS(context.Background(), "example.com")
+		if !errors.Is(err, ErrTimeout) || !errors.Is(err, ErrRequestTimeout) {
+			t.Errorf("TLS() error = %v, want ErrTimeout and ErrRequestTimeout", err)
+		}
+	})
+
+	t.Run("per-call timeout", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+
+		_, err := c.TLS(context.Background(), "example.com", WithRequestTimeout(50*time.Millisecond))
+		if !errors.Is(err, ErrTimeout) {
+			t.Errorf("TLS() error = %v, want ErrTimeout", err)
+		}
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := c.TLS(ctx, "example.com")
+		if !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("TLS() error = %v, want ErrTimeout and context.DeadlineExceeded", err)
+		}
+	})
+}
+
+func TestNonTimeoutErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("API error", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newErrorServer(t, http.StatusNotFound, `{"error": "not found"}`)
+		c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+
+		_, err := c.TLS(context.Background(), "example.com")
+		if err == nil || errors.Is(err, ErrTimeout) {
+			t.Errorf("TLS() error = %v, want a non-timeout error", err)
+		}
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newSleepyServer(t, 5*time.Second)
+		c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		_, err := c.TLS(ctx, "example.com")
+		if !errors.Is(err, context.Canceled) || errors.Is(err, ErrTimeout) {
+			t.Errorf("TLS() error = %v, want context.Canceled without ErrTimeout", err)
+		}
+	})
+}
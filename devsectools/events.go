@@ -0,0 +1,133 @@
+package devsectools
+
+import (
+	"sync"
+	"time"
+)
+
+// EventBufferSize is the number of events buffered for each subscriber. Once a subscriber's buffer is
+// full, further events for it are dropped until it catches up.
+const EventBufferSize = 256
+
+// EventKind identifies what an Event describes.
+type EventKind string
+
+// Kinds of events emitted by the client.
+const (
+	EventRequestStart   EventKind = "request_start"   // A call has started.
+	EventRequestSuccess EventKind = "request_success" // A call has succeeded.
+	EventRequestFailure EventKind = "request_failure" // A call has failed, after any retries.
+	EventRetry          EventKind = "retry"           // An attempt failed and will be retried.
+	EventCacheHit       EventKind = "cache_hit"       // A call was answered from the cache.
+)
+
+// Event describes activity of the client, for dashboards, logging, and metrics.
+type Event struct {
+	Kind     EventKind     // What happened.
+	Time     time.Time     // When it happened.
+	Endpoint string        // The API endpoint path (e.g., "/tls").
+	URL      string        // The scan target, if the call has one.
+	Duration time.Duration // The duration of the call, for success and failure events.
+	Err      error         // The error, for failure and retry events.
+}
+
+// subscriber receives events on its own goroutine, so a slow subscriber can't block requests.
+type subscriber struct {
+	events chan Event
+	once   sync.Once
+}
+
+// Subscribe registers a function to receive every event emitted by the client.
+//
+// Each subscriber is called sequentially on its own goroutine. Events are buffered (see `EventBufferSize`)
+// and dropped for a subscriber that falls too far behind, so a slow subscriber never blocks requests or
+// other subscribers.
+//
+// Parameters:
+//   - fn: The function to call for each event.
+//
+// Returns:
+//   - A function which unsubscribes. It is safe to call more than once.
+func (c *Client) Subscribe(fn func(Event)) (unsubscribe func()) {
+	sub := &subscriber{events: make(chan Event, EventBufferSize)}
+
+	go func() {
+		for event := range sub.events {
+			fn(event)
+		}
+	}()
+
+	c.subscribersMu.Lock()
+	defer c.subscribersMu.Unlock()
+
+	// A closed client emits nothing, so stop the goroutine straight away.
+	if c.closed.Load() {
+		sub.once.Do(func() { close(sub.events) })
+		return func() {}
+	}
+
+	if c.subscribers == nil {
+		c.subscribers = make(map[*subscriber]struct{})
+	}
+	c.subscribers[sub] = struct{}{}
+
+	return func() {
+		c.unsubscribe(sub)
+	}
+}
+
+// unsubscribe removes a subscriber and stops its goroutine once its buffered events are delivered.
+func (c *Client) unsubscribe(sub *subscriber) {
+	c.subscribersMu.Lock()
+	delete(c.subscribers, sub)
+	c.subscribersMu.Unlock()
+
+	// Events are only sent while holding the read lock, so no sends can race with the close.
+	sub.once.Do(func() {
+		close(sub.events)
+	})
+}
+
+// unsubscribeAll removes every subscriber.
+func (c *Client) unsubscribeAll() {
+	c.subscribersMu.RLock()
+	subs := make([]*subscriber, 0, len(c.subscribers))
+	for sub := range c.subscribers {
+		subs = append(subs, sub)
+	}
+	c.subscribersMu.RUnlock()
+
+	for _, sub := range subs {
+		c.unsubscribe(sub)
+	}
+}
+
+// emit sends an event to every subscriber without blocking.
+//
+// Parameters:
+//   - event: The template describing the call.
+//   - kind: What happened.
+//   - err: The error, for failure and retry events.
+//   - duration: The duration of the call, for success and failure events.
+func (c *Client) emit(event Event, kind EventKind, err error, duration time.Duration) {
+	c.record(kind, err)
+
+	c.subscribersMu.RLock()
+	defer c.subscribersMu.RUnlock()
+
+	if len(c.subscribers) == 0 {
+		return
+	}
+
+	event.Kind = kind
+	event.Time = c.clock.Now()
+	event.Err = err
+	event.Duration = duration
+
+	for sub := range c.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package devsectools
+
+import (
+	"context"
+	"fmt"
+)
+
+// fleetMethods are the scans run for each host by `ScanFleet`.
+var fleetMethods = []ScanMethod{MethodDomain, MethodHTTP, MethodTLS}
+
+// ScanFleetStats summarizes the outcome of `ScanFleet`.
+type ScanFleetStats struct {
+	Hosts    int        // Number of distinct hosts scanned.
+	Complete int        // Hosts whose scans all succeeded.
+	Partial  int        // Hosts with some, but not all, scans failed.
+	Failed   int        // Hosts whose scans all failed.
+	Requests BatchStats // The outcome of the individual requests, and the total duration.
+}
+
+// String returns a one-line summary, e.g. "2000 hosts: 1990 complete, 8 partial, 2 failed".
+func (s ScanFleetStats) String() string {
+	return fmt.Sprintf("%d hosts: %d complete, %d partial, %d failed", s.Hosts, s.Complete, s.Partial, s.Failed)
+}
+
+// ScanFleet runs the domain, HTTP, and TLS scans of many hosts as a single batch, and merges the results
+// per host. It is the batch equivalent of calling `ScanAll` for every host, with the concurrency and rate
+// limits of `opts` applying across all hosts and scans (e.g., `MaxConcurrency` bounds the total number of
+// requests in flight, not the number of hosts).
+//
+// Every host gets a `FullScan`, even if some or all of its scans failed: failed scans are left nil and
+// their errors are stored in `FullScan.Errors`. If `opts.ValidateFirst` is set and any URL is empty,
+// nothing is sent and every scan fails with the `*BatchValidationError`. Warnings are added when
+// `Config.EnableWarnings` is set.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - urls: The hosts to scan (e.g., "example.com"). Duplicates are scanned once.
+//   - opts: A `BatchOptions` struct defining the concurrency and rate limits.
+//
+// Returns:
+//   - The scan of every host, keyed by URL as passed.
+//   - A summary of the outcome.
+func (c *Client) ScanFleet(
+	ctx context.Context,
+	urls []string,
+	opts BatchOptions,
+) (map[string]*FullScan, ScanFleetStats) {
+	scans := make(map[string]*FullScan, len(urls))
+	requests := make([]BatchRequest, 0, len(urls)*len(fleetMethods))
+
+	for _, url := range urls {
+		if _, ok := scans[url]; ok {
+			continue
+		}
+		scans[url] = &FullScan{URL: url}
+
+		for _, method := range fleetMethods {
+			requests = append(requests, BatchRequest{Method: method, URL: url})
+		}
+	}
+
+	start := c.clock.Now()
+	if err := c.BatchWithOptions(ctx, requests, opts); err != nil {
+		markBatchErr(requests, err)
+	}
+
+	var stats ScanFleetStats
+	stats.Requests.add(requests)
+	stats.Requests.Duration = c.clock.Now().Sub(start)
+
+	for i := range requests {
+		req := &requests[i]
+		scan := scans[req.URL]
+
+		if req.Err != nil {
+			scan.setErr(req.Method, req.Err)
+			continue
+		}
+		scan.setStatus(req.Method, req.status)
+
+		switch result := req.Result.(type) {
+		case *DomainResponse:
+			scan.Domain = result
+		case *HttpResponse:
+			scan.HTTP = result
+		case *TlsResponse:
+			scan.TLS = result
+		}
+	}
+
+	for _, scan := range scans {
+		stats.Hosts++
+
+		switch len(scan.Errors) {
+		case 0:
+			stats.Complete++
+		case len(fleetMethods):
+			stats.Failed++
+		default:
+			stats.Partial++
+		}
+
+		if c.config.EnableWarnings {
+			scan.Warnings = c.scanWarnings(scan)
+		}
+	}
+
+	return scans, stats
+}
@@ -0,0 +1,220 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// FullScan combines the domain, HTTP, and TLS scans of a single host.
+type FullScan struct {
+	URL    string          // The URL that was scanned.
+	Domain *DomainResponse // The domain scan, or nil if it failed.
+	HTTP   *HttpResponse   // The HTTP scan, or nil if it failed.
+	TLS    *TlsResponse    // The TLS scan, or nil if it failed.
+
+	// Errors holds the error of each scan which failed, by method. It is nil if every scan succeeded.
+	Errors map[ScanMethod]error
+
+	// Warnings lists conditions which deserve attention but aren't failures. It is only populated when
+	// `Config.EnableWarnings` is set.
+	Warnings []Warning
+
+	statuses map[ScanMethod]int // The status of each decoded response, for warnings. Cached ones are absent.
+}
+
+// ScanAll runs the domain, HTTP, and TLS scans of a host concurrently.
+//
+// Parameters:
+//   - ctx: Context for handling timeouts and cancellations.
+//   - url: The domain to scan (e.g., "example.com").
+//   - opts: Optional RequestOptions which customize all three calls.
+//
+// Returns:
+//   - A pointer to a `FullScan` struct. Scans which failed are left nil, so a partial result is returned
+//     alongside an error.
+//   - An error joining the errors of every scan that failed, or `nil` if all succeeded.
+func (c *Client) ScanAll(ctx context.Context, url string, opts ...RequestOption) (*FullScan, error) {
+	scan := &FullScan{URL: url}
+
+	var (
+		wg                                  sync.WaitGroup
+		domainErr, httpErr, tlsErr          error
+		domainStatus, httpStatus, tlsStatus int
+	)
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		if result, err := c.Domain(ctx, url, withStatus(opts, &domainStatus)...); err != nil {
+			domainErr = fmt.Errorf("domain scan: %w", err)
+		} else {
+			scan.Domain = result
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if result, err := c.HTTP(ctx, url, withStatus(opts, &httpStatus)...); err != nil {
+			httpErr = fmt.Errorf("http scan: %w", err)
+		} else {
+			scan.HTTP = result
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if result, err := c.TLS(ctx, url, withStatus(opts, &tlsStatus)...); err != nil {
+			tlsErr = fmt.Errorf("tls scan: %w", err)
+		} else {
+			scan.TLS = result
+		}
+	}()
+	wg.Wait()
+
+	for method, err := range map[ScanMethod]error{MethodDomain: domainErr, MethodHTTP: httpErr, MethodTLS: tlsErr} {
+		if err != nil {
+			scan.setErr(method, err)
+		}
+	}
+	statuses := map[ScanMethod]int{MethodDomain: domainStatus, MethodHTTP: httpStatus, MethodTLS: tlsStatus}
+	for method, status := range statuses {
+		scan.setStatus(method, status)
+	}
+
+	if c.config.EnableWarnings {
+		scan.Warnings = c.scanWarnings(scan)
+	}
+
+	return scan, errors.Join(domainErr, httpErr, tlsErr)
+}
+
+// withStatus appends an option which records the status of the decoded response into `status`, leaving
+// `opts` untouched since the three scans share it.
+func withStatus(opts []RequestOption, status *int) []RequestOption {
+	return append(slices.Clip(opts), reportStatus(status))
+}
+
+// setStatus records the status of a scan's decoded response. Zero, for a cached response, isn't recorded.
+func (f *FullScan) setStatus(method ScanMethod, status int) {
+	if status == 0 {
+		return
+	}
+	if f.statuses == nil {
+		f.statuses = make(map[ScanMethod]int)
+	}
+	f.statuses[method] = status
+}
+
+// setErr records the error of a failed scan.
+func (f *FullScan) setErr(method ScanMethod, err error) {
+	if f.Errors == nil {
+		f.Errors = make(map[ScanMethod]error)
+	}
+	f.Errors[method] = err
+}
+
+// Security score weights. They add up to 100.
+const (
+	scoreHTTP2          = 12 // HTTP/2 is supported.
+	scoreHTTP3          = 8  // HTTP/3 is supported.
+	scoreTLS13          = 25 // TLS 1.3 is the highest version (TLS 1.2 scores `scoreTLS12`).
+	scoreTLS12          = 15 // TLS 1.2 is the highest version.
+	scoreNoDeprecated   = 15 // Neither TLS 1.0 nor TLS 1.1 is enabled.
+	scoreCipherStrength = 40 // Scaled by the strength of the weakest cipher suite offered.
+)
+
+// SecurityScore rates the scanned host from 0 to 100, for thresholding in CI. The score is the sum of:
+//
+//   - HTTP modernity, up to 20: 12 for HTTP/2 and 8 for HTTP/3.
+//   - TLS version posture, up to 40: 25 if TLS 1.3 is the highest version (15 for TLS 1.2), plus 15 if no
+//     deprecated version (TLS 1.0 or TLS 1.1) is enabled.
+//   - Cipher strength, up to 40, by the weakest cipher suite offered under any version, since a client can
+//     be downgraded to it: 40 if recommended, 30 if secure, 10 if weak, and 0 if insecure or unknown.
+//
+// Scans which failed contribute nothing. The score depends only on the scan results, so the same results
+// always produce the same score.
+//
+// Returns:
+//   - The security score, from 0 to 100.
+func (f *FullScan) SecurityScore() int {
+	if f == nil {
+		return 0
+	}
+
+	return httpScore(f.HTTP) + tlsVersionScore(f.TLS) + cipherStrengthScore(f.TLS)
+}
+
+// PassesThreshold reports whether the security score is at least `threshold`.
+//
+// Parameters:
+//   - threshold: The lowest passing score.
+//
+// Returns:
+//   - `true` if `SecurityScore()` is greater than or equal to `threshold`.
+func (f *FullScan) PassesThreshold(threshold int) bool {
+	return f.SecurityScore() >= threshold
+}
+
+// httpScore scores the HTTP versions a host supports.
+func httpScore(r *HttpResponse) int {
+	if r == nil {
+		return 0
+	}
+
+	score := 0
+	if r.HTTP2 {
+		score += scoreHTTP2
+	}
+	if r.HTTP3 {
+		score += scoreHTTP3
+	}
+
+	return score
+}
+
+// tlsVersionScore scores the TLS versions a host supports.
+func tlsVersionScore(r *TlsResponse) int {
+	if len(r.EnabledVersions()) == 0 {
+		return 0
+	}
+
+	score := 0
+	switch highest := r.HighestTLSVersion(); {
+	case highest >= VersionTLS13:
+		score += scoreTLS13
+	case highest == VersionTLS12:
+		score += scoreTLS12
+	}
+
+	if !r.SupportsDeprecatedTLS() {
+		score += scoreNoDeprecated
+	}
+
+	return score
+}
+
+// cipherStrengthScore scores the weakest cipher suite a host offers.
+func cipherStrengthScore(r *TlsResponse) int {
+	weakest, found := StrengthRecommended, false
+	for _, conn := range r.connections() {
+		for _, suite := range conn.CipherSuites {
+			found = true
+			weakest = min(weakest, suite.Rank())
+		}
+	}
+	if !found {
+		return 0
+	}
+
+	switch weakest {
+	case StrengthRecommended:
+		return scoreCipherStrength
+	case StrengthSecure:
+		return scoreCipherStrength * 3 / 4
+	case StrengthWeak:
+		return scoreCipherStrength / 4
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,85 @@
+package devsectools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Gate scans a host's TLS configuration, checks it against a policy, and writes a Markdown report to `w`.
+// It is a single entry point for CI jobs, e.g. `ok, err := client.Gate(ctx, host, ModernPolicy,
+// os.Stderr)`; the pieces it combines (`TLS`, `CheckPolicy`, and `WriteReport`) remain usable on their own.
+//
+// Parameters:
+//   - ctx: Context for handling timeouts and cancellations.
+//   - url: The domain to scan (e.g., "example.com").
+//   - policy: The policy the host must satisfy.
+//   - w: Where to write the report.
+//
+// Returns:
+//   - Whether the host passed the policy.
+//   - An error if the scan failed or the report could not be written. A failing host is not an error.
+func (c *Client) Gate(ctx context.Context, url string, policy CipherPolicy, w io.Writer) (bool, error) {
+	scan, err := c.TLS(ctx, url)
+	if err != nil {
+		return false, err
+	}
+
+	result := scan.CheckPolicy(policy)
+	if err := result.WriteReport(w, scan); err != nil {
+		return false, err
+	}
+
+	return result.Passed, nil
+}
+
+// WriteReport writes a human-readable Markdown report of a policy check.
+//
+// Parameters:
+//   - w: Where to write the report.
+//   - scan: The scan that was checked, for the host name and enabled versions. May be `nil`.
+//
+// Returns:
+//   - An error if writing failed.
+func (r PolicyResult) WriteReport(w io.Writer, scan *TlsResponse) error {
+	var b strings.Builder
+
+	host := ""
+	if scan != nil {
+		host = scan.Hostname
+		if host == "" {
+			host = scan.RequestedURL
+		}
+	}
+
+	status := "PASS"
+	if !r.Passed {
+		status = "FAIL"
+	}
+
+	fmt.Fprintf(&b, "## TLS policy check: %s\n\n", host)
+	fmt.Fprintf(&b, "- Policy: %s\n", r.Policy)
+	fmt.Fprintf(&b, "- Result: **%s**\n", status)
+
+	versions := scan.EnabledVersions()
+	names := make([]string, len(versions))
+	for i, v := range versions {
+		names[i] = v.String()
+	}
+	if len(names) == 0 {
+		names = []string{"none"}
+	}
+	fmt.Fprintf(&b, "- Enabled versions: %s\n", strings.Join(names, ", "))
+
+	if len(r.Violations) > 0 {
+		fmt.Fprintf(&b, "\n### Violations (%d)\n\n", len(r.Violations))
+		for _, v := range r.Violations {
+			fmt.Fprintf(&b, "- `%s`: %s\n", v.Rule, v.Message)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
@@ -0,0 +1,81 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// headerWatch observes a request with `httptrace` to tell whether a timeout was the transport's
+// `ResponseHeaderTimeout`: the request was fully written, no response arrived, and the wait since writing
+// reached the limit.
+type headerWatch struct {
+	clock   clock
+	limit   time.Duration
+	mu      sync.Mutex
+	wroteAt time.Time
+	gotByte bool
+}
+
+// watchHeaders attaches a headerWatch to a context, if `Config.ResponseHeaderTimeout` is set.
+//
+// Parameters:
+//   - ctx: The context the request will be sent with.
+//
+// Returns:
+//   - The context to send the request with.
+//   - The watch, or `nil` if there is no response header timeout.
+func (c *Client) watchHeaders(ctx context.Context) (context.Context, *headerWatch) {
+	if c.config.ResponseHeaderTimeout <= 0 {
+		return ctx, nil
+	}
+
+	w := &headerWatch{clock: c.clock, limit: c.config.ResponseHeaderTimeout}
+	trace := &httptrace.ClientTrace{
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			w.mu.Lock()
+			w.wroteAt = w.clock.Now()
+			w.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			w.mu.Lock()
+			w.gotByte = true
+			w.mu.Unlock()
+		},
+	}
+
+	return httptrace.WithClientTrace(ctx, trace), w
+}
+
+// classify wraps `err` with `ErrResponseHeaderTimeout` if it was caused by the response header timeout.
+//
+// Parameters:
+//   - ctx: The context the request was sent with. A request whose context ended timed out for that
+//     reason instead.
+//   - err: The error from sending the request.
+//
+// Returns:
+//   - The error, wrapped if appropriate.
+func (w *headerWatch) classify(ctx context.Context, err error) error {
+	if w == nil || ctx.Err() != nil {
+		return err
+	}
+
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.gotByte || w.wroteAt.IsZero() || w.clock.Now().Sub(w.wroteAt) < w.limit {
+		return err
+	}
+
+	return fmt.Errorf("%w after %s: %w", ErrResponseHeaderTimeout, w.limit, err)
+}
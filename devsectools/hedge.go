@@ -0,0 +1,74 @@
+package devsectools
+
+import (
+	"context"
+	"net/http"
+)
+
+// hedgedAttempt performs a single logical attempt, sending a second, identical request if the first hasn't
+// completed within `Config.HedgeAfter`. The first successful response wins and the other request is
+// cancelled. Without hedging configured, for non-GET requests, or for streamed responses, it is equivalent
+// to `attempt`.
+//
+// Parameters:
+//   - ctx: A context to allow request cancellation or custom timeouts.
+//   - method: The HTTP method (e.g., "GET").
+//   - endpoint: The API endpoint path (e.g., "/domain").
+//   - payload: The request body (set to `nil` for GET requests).
+//   - ro: The collected per-call settings, including the complete query string.
+//
+// Returns:
+//   - The raw response body of the winning request.
+//   - The winning response, with its body already read, or `nil` if none was received.
+//   - An error if the request, or both requests when hedged, failed.
+func (c *Client) hedgedAttempt(
+	ctx context.Context,
+	method, endpoint string,
+	payload any,
+	ro *requestOptions,
+) ([]byte, *http.Response, error) {
+	if c.config.HedgeAfter <= 0 || method != http.MethodGet || ro.stream != nil {
+		return c.attempt(ctx, method, endpoint, payload, ro)
+	}
+
+	// Cancelling on return stops whichever request lost.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		body []byte
+		resp *http.Response
+		err  error
+	}
+
+	// Buffered so that the losing request never blocks after we return.
+	outcomes := make(chan outcome, 2)
+	launch := func() {
+		go func() {
+			body, resp, err := c.attempt(ctx, method, endpoint, payload, ro)
+			outcomes <- outcome{body, resp, err}
+		}()
+	}
+
+	launch()
+	inFlight := 1
+	hedge := c.clock.After(c.config.HedgeAfter)
+
+	for {
+		select {
+		case o := <-outcomes:
+			inFlight--
+
+			// A failure before the hedge fires is returned as-is, leaving recovery to the retry policy.
+			// Once hedged, a failure only counts if the other request has failed too.
+			if o.err == nil || inFlight == 0 {
+				return o.body, o.resp, o.err
+			}
+
+		case <-hedge:
+			hedge = nil
+			launch()
+			inFlight++
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Package keyring stores DevSecTools API keys in the operating system's credential store (the macOS
+// Keychain, the Windows Credential Manager, or the Secret Service on Linux), so that tools built on the SDK
+// don't need to keep secrets in plaintext config files.
+//
+// Keys are stored per profile, matching the profiles of `devsectools.NewClientWithProfile`. Add `Provider`
+// to a `devsectools.ConfigResolver` to use the stored key:
+//
+//	resolver := devsectools.DefaultConfigResolver()
+//	resolver.Providers = append(resolver.Providers, keyring.Provider(""))
+//	client, err := resolver.NewClient()
+//
+// The credential store isn't always available, e.g. on a headless Linux host without a Secret Service on
+// D-Bus. `Provider` then supplies nothing, so that the other providers still configure the client; call
+// `APIKey` directly to find out why no key was found.
+package keyring
+
+import (
+	"errors"
+	"os"
+
+	"github.com/northwood-labs/devsec-tools-sdk-go/devsectools"
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+// Service is the name under which API keys are stored in the credential store.
+const Service = "devsectools"
+
+// ErrNotFound is returned when the credential store has no API key for the profile.
+var ErrNotFound = errors.New("keyring: API key not found")
+
+// SetAPIKey stores the API key of a profile, replacing any existing key.
+//
+// Parameters:
+//   - profile: The name of the profile. If empty, `DEVSECTOOLS_PROFILE` is used, or else
+//     `devsectools.DefaultProfile`.
+//   - key: The API key.
+//
+// Returns:
+//   - An error if the credential store is unavailable.
+func SetAPIKey(profile, key string) error {
+	return gokeyring.Set(Service, profileName(profile), key)
+}
+
+// APIKey reads the API key of a profile.
+//
+// Parameters:
+//   - profile: The name of the profile. If empty, `DEVSECTOOLS_PROFILE` is used, or else
+//     `devsectools.DefaultProfile`.
+//
+// Returns:
+//   - The API key.
+//   - `ErrNotFound` if there is no key for the profile, or an error if the credential store is unavailable.
+func APIKey(profile string) (string, error) {
+	key, err := gokeyring.Get(Service, profileName(profile))
+	if errors.Is(err, gokeyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+
+	return key, err
+}
+
+// DeleteAPIKey removes the API key of a profile.
+//
+// Parameters:
+//   - profile: The name of the profile. If empty, `DEVSECTOOLS_PROFILE` is used, or else
+//     `devsectools.DefaultProfile`.
+//
+// Returns:
+//   - `ErrNotFound` if there is no key for the profile, or an error if the credential store is unavailable.
+func DeleteAPIKey(profile string) error {
+	err := gokeyring.Delete(Service, profileName(profile))
+	if errors.Is(err, gokeyring.ErrNotFound) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// Provider supplies the API key of a profile from the credential store to a `devsectools.ConfigResolver`.
+// It supplies nothing if there is no key for the profile, or if the credential store is unavailable (e.g.,
+// no Secret Service is running), rather than failing the whole resolution.
+//
+// Parameters:
+//   - profile: The name of the profile. If empty, `DEVSECTOOLS_PROFILE` is used, or else
+//     `devsectools.DefaultProfile`.
+//
+// Returns:
+//   - A `devsectools.ConfigProvider`.
+func Provider(profile string) devsectools.ConfigProvider {
+	return func() ([]devsectools.Option, error) {
+		key, err := APIKey(profile)
+		if err != nil {
+			return nil, nil
+		}
+
+		return []devsectools.Option{devsectools.WithAPIKey(key)}, nil
+	}
+}
+
+// profileName resolves the name of the profile whose key is stored.
+func profileName(profile string) string {
+	if profile == "" {
+		profile = os.Getenv(devsectools.EnvProfile)
+	}
+	if profile == "" {
+		profile = devsectools.DefaultProfile
+	}
+
+	return profile
+}
@@ -0,0 +1,113 @@
+package keyring
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/northwood-labs/devsec-tools-sdk-go/devsectools"
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+// The mock credential store is global, so these tests don't run in parallel.
+
+func TestAPIKeyRoundTrip(t *testing.T) {
+	gokeyring.MockInit()
+	t.Setenv(devsectools.EnvProfile, "")
+
+	if _, err := APIKey("work"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("APIKey() before SetAPIKey error = %v, want ErrNotFound", err)
+	}
+
+	if err := SetAPIKey("work", "key-1"); err != nil {
+		t.Fatalf("SetAPIKey() error = %v", err)
+	}
+	if key, err := APIKey("work"); err != nil || key != "key-1" {
+		t.Errorf("APIKey() = (%q, %v), want key-1", key, err)
+	}
+	if _, err := APIKey(""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("APIKey() of the default profile error = %v, want ErrNotFound", err)
+	}
+
+	if err := DeleteAPIKey("work"); err != nil {
+		t.Fatalf("DeleteAPIKey() error = %v", err)
+	}
+	if err := DeleteAPIKey("work"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DeleteAPIKey() of a deleted key error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestProfileFromEnvironment(t *testing.T) {
+	gokeyring.MockInit()
+	t.Setenv(devsectools.EnvProfile, "ci")
+
+	if err := SetAPIKey("", "key-ci"); err != nil {
+		t.Fatalf("SetAPIKey() error = %v", err)
+	}
+	if key, err := APIKey("ci"); err != nil || key != "key-ci" {
+		t.Errorf("APIKey(%q) = (%q, %v), want the key stored under the environment's profile", "ci", key, err)
+	}
+}
+
+func TestProvider(t *testing.T) {
+	errUnavailable := errors.New("the name org.freedesktop.secrets was not provided by any .service files")
+
+	tests := map[string]struct {
+		init     func()
+		wantAuth string // The Authorization header sent by the resolved client.
+	}{
+		"stored key": {
+			func() {
+				gokeyring.MockInit()
+				_ = gokeyring.Set(Service, devsectools.DefaultProfile, "key-1")
+			},
+			"Bearer key-1",
+		},
+		"no key":            {gokeyring.MockInit, ""},
+		"store unavailable": {func() { gokeyring.MockInitWithError(errUnavailable) }, ""},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.init()
+			t.Setenv(devsectools.EnvProfile, "")
+
+			var auth string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			t.Cleanup(srv.Close)
+
+			resolver := &devsectools.ConfigResolver{Providers: []devsectools.ConfigProvider{
+				Provider(""),
+				devsectools.OptionsProvider(devsectools.WithEndpoint(&devsectools.Endpoint{BaseURL: srv.URL})),
+			}}
+
+			client, err := resolver.NewClient()
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if _, err := client.TLS(context.Background(), "example.com"); err != nil {
+				t.Fatalf("TLS() error = %v", err)
+			}
+			if auth != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", auth, tt.wantAuth)
+			}
+		})
+	}
+}
+
+func TestAPIKeyReportsUnavailableStore(t *testing.T) {
+	errUnavailable := errors.New("dbus: connection refused")
+	gokeyring.MockInitWithError(errUnavailable)
+
+	if _, err := APIKey("work"); !errors.Is(err, errUnavailable) {
+		t.Errorf("APIKey() error = %v, want %v", err, errUnavailable)
+	}
+	if err := SetAPIKey("work", "key-1"); !errors.Is(err, errUnavailable) {
+		t.Errorf("SetAPIKey() error = %v, want %v", err, errUnavailable)
+	}
+}
@@ -0,0 +1,97 @@
+package devsectools
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// semaphore bounds the number of concurrent operations. A nil semaphore is unlimited.
+type semaphore chan struct{}
+
+// newSemaphore creates a semaphore which allows up to `n` concurrent holders.
+//
+// Parameters:
+//   - n: The maximum number of concurrent holders. Values <= 0 mean unlimited.
+//
+// Returns:
+//   - A semaphore, or `nil` if unlimited.
+func newSemaphore(n int) semaphore {
+	if n <= 0 {
+		return nil
+	}
+
+	return make(semaphore, n)
+}
+
+// acquire blocks until a slot is available or the context is done.
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return contextErr(ctx)
+	}
+}
+
+// release frees a slot previously obtained with acquire.
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
+
+// rateLimiter spaces out operations so that no more than a fixed number start per second.
+type rateLimiter struct {
+	clock    clock
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter creates a rate limiter for the given number of operations per second.
+//
+// Parameters:
+//   - clk: The clock used to measure and wait out intervals.
+//   - perSecond: The maximum number of operations to start per second. Values <= 0 mean unlimited.
+//
+// Returns:
+//   - A pointer to a rateLimiter, or `nil` if unlimited.
+func newRateLimiter(clk clock, perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{clock: clk, interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// wait blocks until the caller is allowed to start an operation or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := l.clock.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-l.clock.After(delay):
+		return nil
+	case <-ctx.Done():
+		return contextErr(ctx)
+	}
+}
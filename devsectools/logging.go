@@ -0,0 +1,61 @@
+package devsectools
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// discardLogger is used when no `Config.Logger` is configured.
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// logger returns the configured logger, or one that discards everything.
+func (c *Client) logger() *slog.Logger {
+	if c.config.Logger != nil {
+		return c.config.Logger
+	}
+
+	return discardLogger
+}
+
+// warnInsecureEndpoint logs a warning when `Config.WarnOnInsecureEndpoint` is set and the endpoint sends
+// traffic over plain HTTP to a host which isn't obviously local.
+func (c *Client) warnInsecureEndpoint() {
+	endpoint := c.endpoint()
+	if !c.config.WarnOnInsecureEndpoint || endpoint == nil {
+		return
+	}
+
+	u, err := url.Parse(endpoint.BaseURL)
+	if err != nil || !strings.EqualFold(u.Scheme, "http") || isLocalHost(u.Hostname()) {
+		return
+	}
+
+	c.logger().Warn(
+		"DevSecTools endpoint uses plain HTTP; scan targets will be sent in cleartext",
+		slog.String("baseURL", endpoint.BaseURL),
+	)
+}
+
+// isLocalHost reports whether a hostname refers to the local machine or a private network.
+//
+// Parameters:
+//   - host: The hostname or IP address, without a port.
+//
+// Returns:
+//   - `true` for `localhost`, `*.localhost`, `*.local`, and loopback or private IP addresses.
+func isLocalHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".local") {
+		return true
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast()
+	}
+
+	return false
+}
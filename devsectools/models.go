@@ -2,7 +2,8 @@ package devsectools
 
 // DomainResponse represents a response from /domain endpoint
 type DomainResponse struct {
-	Hostname string `json:"hostname"`
+	Hostname     string `json:"hostname"`
+	RequestedURL string `json:"-"` // The URL passed to the SDK (set client-side, not by the API)
 }
 
 // HttpResponse represents a response from /http endpoint
@@ -11,13 +12,17 @@ type HttpResponse struct {
 	HTTP11   bool   `json:"http11"`
 	HTTP2    bool   `json:"http2"`
 	HTTP3    bool   `json:"http3"`
+
+	RequestedURL string `json:"-"` // The URL passed to the SDK (set client-side, not by the API)
 }
 
 // TlsResponse represents a response from /tls endpoint
 type TlsResponse struct {
-	Hostname     string         `json:"hostname"`
-	TLSVersions  TLSVersions    `json:"tlsVersions"`
-	TLSConn      []TlsConnection `json:"tlsConnections"`
+	Hostname    string          `json:"hostname"`
+	TLSVersions TLSVersions     `json:"tlsVersions"`
+	TLSConn     []TlsConnection `json:"tlsConnections"`
+
+	RequestedURL string `json:"-"` // The URL passed to the SDK (set client-side, not by the API)
 }
 
 // TLSVersions contains TLS support info
@@ -0,0 +1,161 @@
+package devsectools
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMonitorJitter is the fraction of the interval by which `NewMonitor` randomizes each wait.
+const DefaultMonitorJitter = 0.1
+
+// MonitorOptions configures a Monitor.
+type MonitorOptions struct {
+	// Batch controls the concurrency and rate limits of each scan. The rate limit is shared across scans.
+	Batch BatchOptions
+
+	// Jitter randomizes each wait by up to this fraction of the interval in either direction (e.g., 0.1
+	// waits between 90% and 110% of the interval), so that many monitors don't scan in lockstep.
+	Jitter float64
+}
+
+// MonitorResult holds the results of one scan by a Monitor.
+type MonitorResult struct {
+	Time    time.Time     // When the scan started.
+	Results []BatchResult // One result per URL, in the order the URLs were given.
+}
+
+// Monitor re-scans a set of URLs on a schedule, delivering each round of results on a channel.
+type Monitor struct {
+	client   *Client
+	method   ScanMethod
+	urls     []string
+	interval time.Duration
+	jitter   float64
+	runner   *BatchRunner
+
+	results  chan MonitorResult
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewMonitor starts scanning `urls` with `method` immediately and then every `interval`, with
+// `DefaultMonitorJitter`. Read the results from `Results` and call `Stop` when done.
+//
+// Parameters:
+//   - client: The client to scan with.
+//   - method: The API method to call: `MethodDomain`, `MethodHTTP`, or `MethodTLS`.
+//   - urls: The URLs to scan.
+//   - interval: The time between the starts of consecutive scans.
+//
+// Returns:
+//   - A pointer to the running Monitor.
+func NewMonitor(client *Client, method ScanMethod, urls []string, interval time.Duration) *Monitor {
+	return NewMonitorWithOptions(client, method, urls, interval, MonitorOptions{Jitter: DefaultMonitorJitter})
+}
+
+// NewMonitorWithOptions starts a Monitor with custom batch limits and jitter.
+//
+// Parameters:
+//   - client: The client to scan with.
+//   - method: The API method to call: `MethodDomain`, `MethodHTTP`, or `MethodTLS`.
+//   - urls: The URLs to scan.
+//   - interval: The time between the starts of consecutive scans.
+//   - opts: A `MonitorOptions` struct containing the batch limits and jitter.
+//
+// Returns:
+//   - A pointer to the running Monitor.
+func NewMonitorWithOptions(
+	client *Client,
+	method ScanMethod,
+	urls []string,
+	interval time.Duration,
+	opts MonitorOptions,
+) *Monitor {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := &Monitor{
+		client:   client,
+		method:   method,
+		urls:     append([]string(nil), urls...),
+		interval: interval,
+		jitter:   opts.Jitter,
+		runner:   client.NewBatchRunner(opts.Batch),
+		results:  make(chan MonitorResult, 1),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go m.run(ctx)
+
+	return m
+}
+
+// Results returns the channel on which each round of results is delivered. It is closed after `Stop`. A
+// round is held until it is read, which delays the next scan, so read promptly.
+func (m *Monitor) Results() <-chan MonitorResult {
+	return m.results
+}
+
+// Stop cancels any scan in progress and stops the schedule. It waits for the Monitor to shut down and is
+// safe to call more than once.
+func (m *Monitor) Stop() {
+	m.stopOnce.Do(m.cancel)
+	<-m.done
+}
+
+// run scans on schedule until the context is cancelled.
+func (m *Monitor) run(ctx context.Context) {
+	defer close(m.done)
+	defer close(m.results)
+	defer m.runner.Close()
+
+	for {
+		started := m.client.clock.Now()
+
+		requests := make([]BatchRequest, len(m.urls))
+		for i, u := range m.urls {
+			requests[i] = BatchRequest{Method: m.method, URL: u}
+		}
+		_ = m.runner.Run(ctx, requests)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		results := make([]BatchResult, len(requests))
+		for i := range requests {
+			results[i] = newBatchResult(i, &requests[i])
+		}
+
+		select {
+		case m.results <- MonitorResult{Time: started, Results: results}:
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case <-m.client.clock.After(m.nextDelay(m.client.clock.Now().Sub(started))):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// nextDelay calculates the wait before the next scan: the remainder of the interval, randomized by the
+// configured jitter.
+//
+// Parameters:
+//   - elapsed: The time taken by the scan that just finished.
+//
+// Returns:
+//   - The duration to wait. Never negative.
+func (m *Monitor) nextDelay(elapsed time.Duration) time.Duration {
+	delay := m.interval
+	if m.jitter > 0 {
+		delay += time.Duration((m.client.rand.Float64()*2 - 1) * m.jitter * float64(m.interval))
+	}
+
+	return max(delay-elapsed, 0)
+}
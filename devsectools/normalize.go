@@ -0,0 +1,212 @@
+package devsectools
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// Normalizer rewrites a scan target (e.g., "www.Example.com") before it is sent to the API. Returning an
+// error rejects the target, and the call fails without a request being made.
+type Normalizer func(target string) (string, error)
+
+// DefaultNormalizers are applied to scan targets when `Config.Normalizers` is nil.
+var DefaultNormalizers = []Normalizer{TrimSpace, StripTrailingDot, ToASCII, Lowercase}
+
+// idnaProfile converts internationalized host names following the IDNA lookup rules used by browsers, but
+// without the STD3 restriction to letters, digits, and hyphens, so that labels such as "_acme" still work.
+var idnaProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.StrictDomainName(false),
+)
+
+// TrimSpace removes leading and trailing whitespace, which is common in targets pasted from spreadsheets.
+//
+// Parameters:
+//   - target: The scan target.
+//
+// Returns:
+//   - The trimmed target. The error is always `nil`.
+func TrimSpace(target string) (string, error) {
+	return strings.TrimSpace(target), nil
+}
+
+// StripTrailingDot removes a single trailing dot from the target's host, turning a fully-qualified name
+// such as "example.com." into "example.com". Any port or path is left unchanged.
+//
+// Parameters:
+//   - target: The scan target, either a bare host or a URL.
+//
+// Returns:
+//   - The target without the trailing dot. The error is always `nil`.
+func StripTrailingDot(target string) (string, error) {
+	prefix, host, suffix := splitHost(target)
+	return prefix + strings.TrimSuffix(host, ".") + suffix, nil
+}
+
+// Lowercase converts the target's scheme and host to lowercase, so that "Example.com" and "example.com"
+// are scanned (and cached) as the same host. Paths and user information are case-sensitive, so they are
+// left unchanged.
+//
+// Parameters:
+//   - target: The scan target, either a bare host or a URL.
+//
+// Returns:
+//   - The target with a lowercase scheme and host. The error is always `nil`.
+func Lowercase(target string) (string, error) {
+	prefix, host, suffix := splitHost(target)
+	if scheme, userinfo, ok := strings.Cut(prefix, "://"); ok {
+		prefix = strings.ToLower(scheme) + "://" + userinfo
+	}
+
+	return prefix + strings.ToLower(host) + suffix, nil
+}
+
+// StripWWW removes a leading "www." label from the target's host (e.g., "https://www.example.com/" becomes
+// "https://example.com/"). The comparison is case-insensitive.
+//
+// Parameters:
+//   - target: The scan target, either a bare host or a URL.
+//
+// Returns:
+//   - The target without the "www." label. The error is always `nil`.
+func StripWWW(target string) (string, error) {
+	prefix, host, suffix := splitHost(target)
+	if len(host) > len("www.") && strings.EqualFold(host[:len("www.")], "www.") {
+		host = host[len("www."):]
+	}
+
+	return prefix + host + suffix, nil
+}
+
+// ToASCII converts the target's host to its ASCII (Punycode) form with `ToASCIIHost`, leaving any scheme,
+// port, and path unchanged.
+//
+// Parameters:
+//   - target: The scan target, either a bare host or a URL.
+//
+// Returns:
+//   - The target with an ASCII host.
+//   - An error matching `ErrInvalidURL` if the host isn't a valid internationalized domain name.
+func ToASCII(target string) (string, error) {
+	prefix, host, suffix := splitHost(target)
+
+	ascii, err := ToASCIIHost(host)
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + ascii + suffix, nil
+}
+
+// ToASCIIHost converts an internationalized host name to its ASCII (Punycode) form, following the IDNA
+// lookup rules used by browsers (e.g., "müller.de" becomes "xn--mller-kva.de"). Hosts which are already
+// ASCII, including IP addresses and names with underscores such as "_acme.example.com", are returned
+// unchanged; the API decides whether they are valid.
+//
+// Parameters:
+//   - host: The host name or IP address, without a scheme or port. IPv6 addresses may be bracketed.
+//
+// Returns:
+//   - The ASCII host name.
+//   - An error matching `ErrInvalidURL` if the host isn't valid UTF-8 or isn't a valid internationalized
+//     domain name.
+func ToASCIIHost(host string) (string, error) {
+	if !utf8.ValidString(host) {
+		return "", fmt.Errorf("%w: host %q is not valid UTF-8", ErrInvalidURL, host)
+	}
+
+	if isASCII(host) || net.ParseIP(strings.Trim(host, "[]")) != nil {
+		return host, nil
+	}
+
+	ascii, err := idnaProfile.ToASCII(host)
+	if err != nil {
+		return "", fmt.Errorf("%w: host %q: %w", ErrInvalidURL, host, err)
+	}
+
+	// The profile allows any ASCII, for underscores; reject the rest, which can't appear in a host name.
+	if i := strings.IndexFunc(ascii, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.')
+	}); i >= 0 {
+		return "", fmt.Errorf("%w: host %q contains %q", ErrInvalidURL, host, ascii[i])
+	}
+
+	return ascii, nil
+}
+
+// isASCII reports whether a string contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+// splitHost splits a scan target around its host, so that normalizers can rewrite the host alone.
+//
+// Parameters:
+//   - target: A bare host (e.g., "example.com") or a URL (e.g., "https://example.com:8443/path").
+//
+// Returns:
+//   - Everything before the host, such as the scheme and user information (e.g., "https://user@").
+//   - The host. IPv6 literals keep their brackets (e.g., "[2001:db8::1]").
+//   - Everything after the host, such as the port and path (e.g., ":8443/path").
+func splitHost(target string) (prefix, host, suffix string) {
+	rest := target
+	if i := strings.Index(rest, "://"); i >= 0 {
+		prefix, rest = rest[:i+len("://")], rest[i+len("://"):]
+	}
+
+	end := strings.IndexAny(rest, "/?#")
+	if end < 0 {
+		end = len(rest)
+	}
+	host, suffix = rest[:end], rest[end:]
+
+	if i := strings.LastIndexByte(host, '@'); i >= 0 {
+		prefix, host = prefix+host[:i+1], host[i+1:]
+	}
+
+	// An unbracketed host with several colons is a bare IPv6 address (e.g., "2001:db8::1"), which has no
+	// port. Otherwise, split off a trailing port, leaving bracketed IPv6 literals (e.g., "[::1]:8443") whole.
+	if strings.Count(host, ":") > 1 && !strings.HasPrefix(host, "[") {
+		return prefix, host, suffix
+	}
+	if i := strings.LastIndexByte(host, ':'); i >= 0 && !strings.Contains(host[i:], "]") {
+		host, suffix = host[:i], host[i:]+suffix
+	}
+
+	return prefix, host, suffix
+}
+
+// normalizeTarget applies the configured normalizers to a scan target, in order.
+//
+// Parameters:
+//   - target: The scan target as passed by the caller.
+//
+// Returns:
+//   - The normalized target.
+//   - The first error returned by a normalizer.
+func (c *Client) normalizeTarget(target string) (string, error) {
+	normalizers := c.config.Normalizers
+	if normalizers == nil {
+		normalizers = DefaultNormalizers
+	}
+
+	for _, normalize := range normalizers {
+		var err error
+		if target, err = normalize(target); err != nil {
+			return "", err
+		}
+	}
+
+	return target, nil
+}
@@ -0,0 +1,156 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDefaultNormalizers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		target string
+		want   string
+	}{
+		{name: "bare host", target: "example.com", want: "example.com"},
+		{name: "uppercase host", target: "Example.COM", want: "example.com"},
+		{name: "whitespace and trailing dot", target: "  example.com.\t", want: "example.com"},
+		{name: "IDN", target: "müller.de", want: "xn--mller-kva.de"},
+		{name: "uppercase IDN", target: "MÜLLER.de", want: "xn--mller-kva.de"},
+		{name: "IDN URL", target: "https://bücher.example/Path?q=1", want: "https://xn--bcher-kva.example/Path?q=1"},
+		{name: "IDN with port", target: "bücher.example:8443", want: "xn--bcher-kva.example:8443"},
+		{name: "IDN with underscore label", target: "_acme.müller.de", want: "_acme.xn--mller-kva.de"},
+		{name: "underscore", target: "_acme.example.com", want: "_acme.example.com"},
+		{name: "IPv4", target: "192.0.2.1", want: "192.0.2.1"},
+		{name: "IPv4 with port", target: "192.0.2.1:8443", want: "192.0.2.1:8443"},
+		{name: "bracketed IPv6", target: "[2001:db8::1]", want: "[2001:db8::1]"},
+		{name: "bare IPv6", target: "2001:DB8::1", want: "2001:db8::1"},
+		{name: "IPv6 URL with port", target: "https://[2001:db8::1]:8443/", want: "https://[2001:db8::1]:8443/"},
+		{name: "userinfo", target: "https://User@Example.com/Path", want: "https://User@example.com/Path"},
+		{
+			name:   "userinfo with password",
+			target: "HTTPS://User:Pa@ss@Example.com",
+			want:   "https://User:Pa@ss@example.com",
+		},
+		{name: "scheme and port", target: "HTTPS://Example.com:8443/Path", want: "https://example.com:8443/Path"},
+		{name: "trailing dot with port", target: "Example.com.:8443", want: "example.com:8443"},
+		{name: "trailing dot with path", target: " https://Example.com./Path/ ", want: "https://example.com/Path/"},
+		{name: "only one trailing dot", target: "example.com..", want: "example.com."},
+	}
+
+	c := NewClient()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := c.normalizeTarget(tt.target)
+			if err != nil {
+				t.Fatalf("normalizeTarget(%q) error = %v", tt.target, err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeTarget(%q) = %q, want %q", tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToASCIIHostInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, host := range []string{"\xff.example", "bü cher.example", "a‍b.example"} {
+		if _, err := ToASCIIHost(host); !errors.Is(err, ErrInvalidURL) {
+			t.Errorf("ToASCIIHost(%q) error = %v, want ErrInvalidURL", host, err)
+		}
+	}
+}
+
+func TestScanSendsNormalizedTarget(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query().Get("url")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+
+	tests := map[string]string{
+		"[2001:db8::1]":               "[2001:db8::1]",
+		"https://[2001:db8::1]:8443/": "https://[2001:db8::1]:8443/",
+		"_acme.example.com":           "_acme.example.com",
+		"https://User@Example.com/":   "https://User@example.com/",
+		"Bücher.example":              "xn--bcher-kva.example",
+	}
+	for target, want := range tests {
+		resp, err := c.TLS(context.Background(), target)
+		if err != nil {
+			t.Fatalf("TLS(%q) error = %v", target, err)
+		}
+		if got != want {
+			t.Errorf("TLS(%q) sent url=%q, want %q", target, got, want)
+		}
+		if resp.RequestedURL != target {
+			t.Errorf("TLS(%q) RequestedURL = %q, want the original target", target, resp.RequestedURL)
+		}
+	}
+}
+
+func TestEntryPointsNormalizeInput(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu  sync.Mutex
+		got []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		got = append(got, r.URL.Path+" "+r.URL.Query().Get("url"))
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+	ctx := context.Background()
+	const input = "  Example.COM.\n"
+
+	if _, err := c.Domain(ctx, input); err != nil {
+		t.Fatalf("Domain() error = %v", err)
+	}
+	if _, err := c.HTTP(ctx, input); err != nil {
+		t.Fatalf("HTTP() error = %v", err)
+	}
+	if _, err := c.TLS(ctx, input); err != nil {
+		t.Fatalf("TLS() error = %v", err)
+	}
+
+	requests := []BatchRequest{{Method: MethodTLS, URL: input}}
+	c.Batch(ctx, requests)
+	if requests[0].Err != nil {
+		t.Fatalf("Batch() error = %v", requests[0].Err)
+	}
+
+	want := []string{"/domain example.com", "/http example.com", "/tls example.com", "/tls example.com"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("server received %q, want %q", got, want)
+	}
+}
+
+func TestNormalizersDisabled(t *testing.T) {
+	t.Parallel()
+
+	c := NewClientWithConfig(&Config{Endpoint: &PRODUCTION, Timeout: DefaultTimeout, Normalizers: []Normalizer{}})
+
+	if got, err := c.normalizeTarget(" Example.com. "); err != nil || got != " Example.com. " {
+		t.Errorf("normalizeTarget() = (%q, %v), want the target unchanged", got, err)
+	}
+}
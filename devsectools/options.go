@@ -0,0 +1,254 @@
+package devsectools
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestOption customizes a single API call without changing the client's configuration.
+type RequestOption func(*requestOptions)
+
+// requestOptions holds the per-call settings collected from RequestOptions.
+type requestOptions struct {
+	query            url.Values            // Query parameters; the SDK's own are merged in before the request is built.
+	maxResponseBytes int64                 // Overrides `Config.MaxResponseBytes` when greater than zero.
+	timeout          time.Duration         // Overrides `Config.Timeout` when greater than zero.
+	retries          *int                  // Overrides `RetryPolicy.MaxRetries` when set.
+	header           http.Header           // Extra headers, replacing `Config.DefaultHeaders` with the same name.
+	forceRefresh     bool                  // Skip reading the cache, but store the fresh response.
+	noCache          bool                  // Neither read nor write the cache.
+	cacheHit         *bool                 // Set to whether the response was served from the cache.
+	status           *int                  // Set to the status of the decoded response; left alone if cached.
+	basicAuth        *BasicAuth            // Overrides `Config.BasicAuth` when set.
+	acceptLanguage   string                // Overrides `Config.AcceptLanguage` when not empty.
+	connectIP        string                // Dial this address instead of resolving the API host.
+	endpoint         *Endpoint             // Overrides `Config.Endpoint` when set, from `Config.EndpointSelector`.
+	stream           func(io.Reader) error // Consumes a successful response body in place of reading it.
+}
+
+// newRequestOptions applies RequestOptions in order.
+//
+// Parameters:
+//   - opts: The options passed to the API call.
+//
+// Returns:
+//   - A pointer to the collected per-call settings.
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	ro := &requestOptions{}
+	for _, opt := range opts {
+		opt(ro)
+	}
+
+	return ro
+}
+
+// mergeQuery combines the query parameters set by the SDK with those added by RequestOptions. Values for the
+// same key are appended, SDK values first, so neither set replaces the other.
+//
+// Parameters:
+//   - base: The SDK's query parameters. May be `nil`.
+//   - extra: The query parameters from RequestOptions. May be `nil`.
+//
+// Returns:
+//   - A new `url.Values` containing both.
+func mergeQuery(base, extra url.Values) url.Values {
+	merged := make(url.Values, len(base)+len(extra))
+	for _, values := range []url.Values{base, extra} {
+		for key, vals := range values {
+			merged[key] = append(merged[key], vals...)
+		}
+	}
+
+	return merged
+}
+
+// WithQueryParam adds a query string parameter to the request, for API parameters that the typed methods
+// don't know about yet (e.g., `WithQueryParam("deep", "true")`). The value is escaped automatically.
+//
+// Parameters are added alongside the ones set by the SDK (such as `url`) rather than replacing them, and
+// repeating the option with the same key appends another value, matching `url.Values.Add`.
+//
+// Parameters:
+//   - key: The query parameter name.
+//   - value: The query parameter value.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithQueryParam(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.query == nil {
+			o.query = url.Values{}
+		}
+		o.query.Add(key, value)
+	}
+}
+
+// WithMaxResponseBytes limits the size of the response body for this call, overriding
+// `Config.MaxResponseBytes`. Use it to bound a call to an untrusted custom endpoint more tightly than the
+// rest. The limit is enforced while reading, so at most `n` bytes (plus one) are ever buffered. Exceeding
+// it returns a `*ResponseTooLargeError`.
+//
+// Parameters:
+//   - n: The maximum response body size, in bytes. Values <= 0 leave the client setting in effect.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithMaxResponseBytes(n int64) RequestOption {
+	return func(o *requestOptions) {
+		o.maxResponseBytes = n
+	}
+}
+
+// WithRequestTimeout sets the network timeout for this call, overriding `Config.Timeout`, so that a slow
+// scan can be given longer without a separate client. Like `Config.Timeout`, it applies to each attempt,
+// so retries each get the full timeout. The context passed to the call still bounds it as a whole.
+//
+// Parameters:
+//   - timeout: The timeout duration (e.g., `30*time.Second`). Values <= 0 leave the client setting in effect.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithNoRetry makes a single attempt for this call, even if the client has a `RetryPolicy`. This suits
+// interactive tools, where failing fast and letting the user retry is preferable.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithNoRetry() RequestOption {
+	return WithRetries(0)
+}
+
+// WithRetries sets the number of retries for this call, overriding `RetryPolicy.MaxRetries`. The delays
+// come from the client's `RetryPolicy`, or `DefaultRetryPolicy` if the client has none.
+//
+// Per-call options take precedence over the client's `RetryPolicy`; when several retry options are passed
+// to the same call, the last one wins.
+//
+// Parameters:
+//   - n: The number of retries after the first attempt. Values < 0 are treated as 0.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithRetries(n int) RequestOption {
+	n = max(n, 0)
+
+	return func(o *requestOptions) {
+		o.retries = &n
+	}
+}
+
+// WithHeader sets a header on the request, replacing any default header with the same name from
+// `Config.DefaultHeaders`. Repeating the option with the same name adds another value.
+//
+// Parameters:
+//   - key: The header name. It is canonicalized (e.g., "x-debug" becomes "X-Debug").
+//   - value: The header value.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// WithQueryValues adds a set of query string parameters to the request, including repeated parameters
+// (e.g., `url.Values{"version": {"tls12", "tls13"}}` produces `?version=tls12&version=tls13`).
+//
+// Values are appended to those set by the SDK and by other options rather than replacing them. In
+// particular, adding a `url` value doesn't replace the scan target: both are sent, with the scan target
+// first.
+//
+// Parameters:
+//   - values: The query parameters to add. The map is copied.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithQueryValues(values url.Values) RequestOption {
+	values = mergeQuery(nil, values)
+
+	return func(o *requestOptions) {
+		o.query = mergeQuery(o.query, values)
+	}
+}
+
+// WithForceRefresh fetches a fresh response from the API for this call, ignoring any cached response, and
+// stores the fresh response in the cache. It fails with `ErrOffline` when `Config.Offline` is set.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithForceRefresh() RequestOption {
+	return func(o *requestOptions) {
+		o.forceRefresh = true
+	}
+}
+
+// WithNoCache bypasses the cache entirely for this call: the response is fetched from the API and is
+// neither read from nor stored in the cache. It fails with `ErrOffline` when `Config.Offline` is set.
+//
+// Calls use the cache in one of three modes:
+//   - Normal: a cached response is used if present; otherwise a fresh response is fetched and stored.
+//   - `WithForceRefresh`: a fresh response is always fetched, and stored for later calls.
+//   - `WithNoCache`: a fresh response is always fetched, and the cache is left untouched.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithNoCache() RequestOption {
+	return func(o *requestOptions) {
+		o.noCache = true
+	}
+}
+
+// reportCacheHit records whether the response was served from the cache into `hit`.
+func reportCacheHit(hit *bool) RequestOption {
+	return func(o *requestOptions) {
+		o.cacheHit = hit
+	}
+}
+
+// reportStatus records the status of the response which was decoded into `status`. It is left unchanged
+// when the response is served from the cache.
+func reportStatus(status *int) RequestOption {
+	return func(o *requestOptions) {
+		o.status = status
+	}
+}
+
+// WithBasicAuth sends HTTP Basic credentials with the request, overriding `Config.BasicAuth`. It can't be
+// combined with `Config.Authenticator`.
+//
+// Parameters:
+//   - username: The user name.
+//   - password: The password.
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithBasicAuth(username, password string) RequestOption {
+	return func(o *requestOptions) {
+		o.basicAuth = &BasicAuth{Username: username, Password: password}
+	}
+}
+
+// WithAcceptLanguage requests error messages from the API in the given languages, overriding
+// `Config.AcceptLanguage`.
+//
+// Parameters:
+//   - languages: An `Accept-Language` header value (e.g., "fr-CH, fr;q=0.9, en;q=0.5").
+//
+// Returns:
+//   - A RequestOption to pass to an API call.
+func WithAcceptLanguage(languages string) RequestOption {
+	return func(o *requestOptions) {
+		o.acceptLanguage = languages
+	}
+}
@@ -0,0 +1,227 @@
+package devsectools
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Rules reported in a `PolicyViolation`.
+const (
+	RuleMinTLSVersion = "min-tls-version"
+	RuleAllowedCipher = "allowed-cipher-suites"
+	RuleRequirePFS    = "require-pfs"
+	RuleRequireAEAD   = "require-aead"
+)
+
+// CipherPolicy describes the TLS configuration a host is required to have.
+type CipherPolicy struct {
+	Name                string     // A human-readable name for reports.
+	MinTLSVersion       TLSVersion // The oldest TLS version allowed to be enabled (0 = no minimum).
+	AllowedCipherSuites []string   // IANA names of the permitted cipher suites (empty = any).
+	RequirePFS          bool       // Every cipher suite must provide Perfect Forward Secrecy.
+	RequireAEAD         bool       // Every cipher suite must use an AEAD cipher.
+}
+
+// PolicyViolation describes a single way in which a scan fails a policy.
+type PolicyViolation struct {
+	Rule        string // The rule that was violated (e.g., `RuleRequirePFS`).
+	Version     string // The TLS version the violation was found under, if applicable.
+	CipherSuite string // The IANA name of the offending cipher suite, if applicable.
+	Message     string // A human-readable description.
+}
+
+// PolicyResult is the outcome of checking a scan against a `CipherPolicy`.
+type PolicyResult struct {
+	Policy     string            // The name of the policy that was checked.
+	Passed     bool              // Whether the scan satisfies the policy.
+	Violations []PolicyViolation // Every violation found, empty if the scan passed.
+}
+
+// Built-in policies.
+var (
+	// ModernPolicy requires TLS 1.3 only, with forward-secret AEAD cipher suites.
+	ModernPolicy = CipherPolicy{
+		Name:          "modern",
+		MinTLSVersion: VersionTLS13,
+		RequirePFS:    true,
+		RequireAEAD:   true,
+	}
+
+	// IntermediatePolicy allows TLS 1.2 and newer, with forward-secret AEAD cipher suites.
+	IntermediatePolicy = CipherPolicy{
+		Name:          "intermediate",
+		MinTLSVersion: VersionTLS12,
+		RequirePFS:    true,
+		RequireAEAD:   true,
+	}
+)
+
+// CheckPolicy checks the scan against a cipher policy.
+//
+// A host with no TLS versions enabled fails every policy. Cipher suite rules are checked under every
+// enabled version, including versions that already violate the minimum.
+//
+// Parameters:
+//   - p: The policy to check against.
+//
+// Returns:
+//   - A `PolicyResult` with pass/fail and every violation found.
+func (r *TlsResponse) CheckPolicy(p CipherPolicy) PolicyResult {
+	result := PolicyResult{Policy: p.Name}
+
+	versions := r.EnabledVersions()
+	if len(versions) == 0 {
+		result.Violations = append(result.Violations, PolicyViolation{
+			Rule:    RuleMinTLSVersion,
+			Message: "no TLS versions are enabled",
+		})
+	}
+
+	for _, v := range versions {
+		if p.MinTLSVersion != 0 && v < p.MinTLSVersion {
+			result.Violations = append(result.Violations, PolicyViolation{
+				Rule:    RuleMinTLSVersion,
+				Version: v.String(),
+				Message: fmt.Sprintf("%s is enabled, but the minimum is %s", v, p.MinTLSVersion),
+			})
+		}
+	}
+
+	for _, conn := range r.connections() {
+		for _, suite := range conn.CipherSuites {
+			if len(p.AllowedCipherSuites) > 0 && !containsFold(p.AllowedCipherSuites, suite.IANAName) {
+				result.Violations = append(result.Violations, PolicyViolation{
+					Rule:        RuleAllowedCipher,
+					Version:     conn.Version,
+					CipherSuite: suite.IANAName,
+					Message:     suite.IANAName + " is not an allowed cipher suite",
+				})
+			}
+
+			if p.RequirePFS && !suite.IsPFS {
+				result.Violations = append(result.Violations, PolicyViolation{
+					Rule:        RuleRequirePFS,
+					Version:     conn.Version,
+					CipherSuite: suite.IANAName,
+					Message:     suite.IANAName + " does not provide forward secrecy",
+				})
+			}
+
+			if p.RequireAEAD && !suite.IsAEAD {
+				result.Violations = append(result.Violations, PolicyViolation{
+					Rule:        RuleRequireAEAD,
+					Version:     conn.Version,
+					CipherSuite: suite.IANAName,
+					Message:     suite.IANAName + " is not an AEAD cipher",
+				})
+			}
+		}
+	}
+
+	result.Passed = len(result.Violations) == 0
+
+	return result
+}
+
+// policyFile is the JSON representation of a CipherPolicy, read by `LoadCipherPolicy` and written by
+// `MarshalPolicy`.
+type policyFile struct {
+	Name                string   `json:"name,omitempty"`
+	MinTLSVersion       string   `json:"minTLSVersion,omitempty"`
+	AllowedCipherSuites []string `json:"allowedCipherSuites,omitempty"`
+	RequirePFS          bool     `json:"requirePFS,omitempty"`
+	RequireAEAD         bool     `json:"requireAEAD,omitempty"`
+}
+
+// LoadCipherPolicy reads a cipher policy from JSON, so that policies can be kept in version control and
+// loaded at runtime. Every field is optional:
+//
+//	{
+//	  "name": "internal-services",
+//	  "minTLSVersion": "TLS 1.2",
+//	  "allowedCipherSuites": ["TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384"],
+//	  "requirePFS": true,
+//	  "requireAEAD": true
+//	}
+//
+// "minTLSVersion" accepts any form understood by `ParseTLSVersion`. Unknown fields are rejected, so that
+// a misspelled rule fails loudly instead of being silently ignored.
+//
+// Parameters:
+//   - r: The JSON document to read.
+//
+// Returns:
+//   - The policy.
+//   - An error if the document is malformed, has unknown fields, or has invalid values.
+func LoadCipherPolicy(r io.Reader) (CipherPolicy, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var file policyFile
+	if err := dec.Decode(&file); err != nil {
+		return CipherPolicy{}, fmt.Errorf("devsectools: invalid cipher policy: %w", err)
+	}
+	if dec.More() {
+		return CipherPolicy{}, errors.New("devsectools: invalid cipher policy: unexpected data after the policy")
+	}
+
+	policy := CipherPolicy{
+		Name:                file.Name,
+		AllowedCipherSuites: file.AllowedCipherSuites,
+		RequirePFS:          file.RequirePFS,
+		RequireAEAD:         file.RequireAEAD,
+	}
+
+	if file.MinTLSVersion != "" {
+		version, err := ParseTLSVersion(file.MinTLSVersion)
+		if err != nil {
+			return CipherPolicy{}, fmt.Errorf("devsectools: invalid cipher policy: minTLSVersion: %w", err)
+		}
+		policy.MinTLSVersion = version
+	}
+
+	for i, name := range policy.AllowedCipherSuites {
+		if strings.TrimSpace(name) == "" {
+			return CipherPolicy{}, fmt.Errorf("devsectools: invalid cipher policy: allowedCipherSuites[%d] is empty", i)
+		}
+	}
+
+	return policy, nil
+}
+
+// MarshalPolicy writes a cipher policy as JSON in the format read by `LoadCipherPolicy`.
+//
+// Parameters:
+//   - p: The policy to write.
+//
+// Returns:
+//   - The indented JSON document, ending in a newline.
+//   - An error if the policy's minimum TLS version is unknown.
+func MarshalPolicy(p CipherPolicy) ([]byte, error) {
+	file := policyFile{
+		Name:                p.Name,
+		AllowedCipherSuites: p.AllowedCipherSuites,
+		RequirePFS:          p.RequirePFS,
+		RequireAEAD:         p.RequireAEAD,
+	}
+
+	if p.MinTLSVersion != 0 {
+		if _, err := ParseTLSVersion(p.MinTLSVersion.String()); err != nil {
+			return nil, err
+		}
+		file.MinTLSVersion = p.MinTLSVersion.String()
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
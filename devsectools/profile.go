@@ -0,0 +1,165 @@
+package devsectools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// DefaultProfile is the profile used by `NewClientWithProfile` when none is named.
+const DefaultProfile = "default"
+
+// Environment variables read by `NewClientWithProfile`.
+const (
+	EnvProfile    = "DEVSECTOOLS_PROFILE"     // The profile to use when none is named.
+	EnvConfigFile = "DEVSECTOOLS_CONFIG_FILE" // The path of the config file, replacing `~/.devsectools/config`.
+)
+
+// profileConfig is one profile in the config file.
+type profileConfig struct {
+	Endpoint string `toml:"endpoint"` // "production", "localdev", or the base URL of a custom endpoint.
+	Timeout  string `toml:"timeout"`  // A duration, as accepted by `time.ParseDuration` (e.g., "10s").
+	APIKey   string `toml:"api_key"`  // A bearer token sent with every request.
+}
+
+// DefaultConfigFile returns the path of the config file read by `NewClientWithProfile`: the value of
+// `DEVSECTOOLS_CONFIG_FILE` if set, otherwise `.devsectools/config` in the user's home directory.
+//
+// Returns:
+//   - The path of the config file.
+//   - An error if the home directory can't be determined.
+func DefaultConfigFile() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".devsectools", "config"), nil
+}
+
+// NewClientWithProfile initializes a new API client from a named profile in the config file (see
+// `DefaultConfigFile`), similar to the profiles of the AWS SDK. The file is TOML, with one table per
+// profile:
+//
+//	[default]
+//	endpoint = "production"
+//
+//	[staging]
+//	endpoint = "https://staging.devsec.example.com"
+//	timeout = "10s"
+//	api_key = "..."
+//
+//	[local]
+//	endpoint = "localdev"
+//
+// Every setting is optional, and takes the same values as the matching `NewClientFromEnv` variable;
+// settings which are left out keep the defaults of `NewClient`.
+//
+// Parameters:
+//   - profile: The name of the profile. If empty, `DEVSECTOOLS_PROFILE` is used, or else `DefaultProfile`.
+//   - opts: Optional Options, which take precedence over the profile.
+//
+// Returns:
+//   - A pointer to the newly created Client.
+//   - An error if the file can't be read or parsed, or matching `ErrProfileNotFound` if it has no such
+//     profile.
+func NewClientWithProfile(profile string, opts ...Option) (*Client, error) {
+	path, err := DefaultConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	profileOpts, err := LoadProfile(path, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(append(profileOpts, opts...)...), nil
+}
+
+// LoadProfile reads a named profile from a config file in the format described by `NewClientWithProfile`.
+//
+// Parameters:
+//   - path: The path of the config file.
+//   - profile: The name of the profile. If empty, `DEVSECTOOLS_PROFILE` is used, or else `DefaultProfile`.
+//
+// Returns:
+//   - The Options to pass to `NewClient` for the profile's settings.
+//   - An error if the file can't be read or parsed, or matching `ErrProfileNotFound` if it has no such
+//     profile.
+func LoadProfile(path, profile string) ([]Option, error) {
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	var profiles map[string]profileConfig
+
+	meta, err := toml.DecodeFile(path, &profiles)
+	if err != nil {
+		return nil, fmt.Errorf("devsectools: reading config file: %w", err)
+	}
+
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, key := range undecoded {
+			keys[i] = key.String()
+		}
+		slices.Sort(keys)
+
+		return nil, fmt.Errorf("devsectools: %s: unknown settings: %s", path, strings.Join(keys, ", "))
+	}
+
+	cfg, ok := profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, profile, path)
+	}
+
+	opts, err := cfg.options()
+	if err != nil {
+		return nil, fmt.Errorf("devsectools: %s: profile %q: %w", path, profile, err)
+	}
+
+	return opts, nil
+}
+
+// options converts the profile's settings into Options.
+//
+// Returns:
+//   - The Options for the settings which are set.
+//   - An error naming the setting if any is invalid.
+func (p profileConfig) options() ([]Option, error) {
+	var opts []Option
+
+	if value := strings.TrimSpace(p.Endpoint); value != "" {
+		endpoint, err := parseEndpoint(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint: %w", err)
+		}
+		opts = append(opts, WithEndpoint(endpoint))
+	}
+
+	if value := strings.TrimSpace(p.Timeout); value != "" {
+		timeout, err := parseTimeout(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		opts = append(opts, WithTimeout(timeout))
+	}
+
+	if value := strings.TrimSpace(p.APIKey); value != "" {
+		opts = append(opts, WithAPIKey(value))
+	}
+
+	return opts, nil
+}
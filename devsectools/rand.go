@@ -0,0 +1,59 @@
+package devsectools
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// randSource abstracts random number generation so that randomized behavior (retry backoff and monitor
+// jitter) can be made deterministic in tests. `*rand.Rand` satisfies it.
+type randSource interface {
+	Int64N(n int64) int64
+	Float64() float64
+}
+
+// globalRand is the default random source, backed by the automatically seeded global generator of
+// `math/rand/v2`. It is safe for concurrent use.
+type globalRand struct{}
+
+// Int64N returns a pseudo-random number in [0, n).
+func (globalRand) Int64N(n int64) int64 { return rand.Int64N(n) }
+
+// Float64 returns a pseudo-random number in [0.0, 1.0).
+func (globalRand) Float64() float64 { return rand.Float64() }
+
+// lockedRand makes a random source, such as a seeded `*rand.Rand`, safe for concurrent use.
+type lockedRand struct {
+	mu  sync.Mutex
+	src randSource
+}
+
+// Int64N returns a pseudo-random number in [0, n).
+func (r *lockedRand) Int64N(n int64) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.src.Int64N(n)
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0).
+func (r *lockedRand) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.src.Float64()
+}
+
+// setRand replaces the random source used by the client. It exists for tests which need deterministic
+// backoff and jitter, together with `setClock`; it is not a public feature.
+//
+// Parameters:
+//   - src: The random source to use, typically `rand.New(rand.NewPCG(seed1, seed2))`. It needn't be safe
+//     for concurrent use. A `nil` value restores the default source.
+func (c *Client) setRand(src randSource) {
+	if src == nil {
+		c.rand = globalRand{}
+		return
+	}
+	c.rand = &lockedRand{src: src}
+}
@@ -0,0 +1,123 @@
+package devsectools
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// seeded returns a random source with a fixed seed. Two sources from it produce the same sequence, so a
+// test can predict the values drawn by the code under test.
+func seeded() *rand.Rand {
+	return rand.New(rand.NewPCG(1, 2))
+}
+
+func TestRetryBackoffJitter(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxRetries: 5, BaseDelay: time.Second, MaxDelay: 6 * time.Second}
+	src, want := seeded(), seeded()
+
+	// Each delay is half the capped exponential delay, plus a random part of the other half.
+	for attempt, full := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 6 * time.Second} {
+		half := full / 2
+		expected := half + time.Duration(want.Int64N(int64(full-half)))
+
+		if got := policy.backoff(attempt, src); got != expected {
+			t.Errorf("backoff(%d) = %s, want %s", attempt, got, expected)
+		}
+	}
+}
+
+func TestSendBackoffIsSeeded(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newStatusServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	clk := newFakeClock()
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	c := NewClientWithConfig(&Config{
+		Endpoint:    &Endpoint{BaseURL: srv.URL},
+		Timeout:     DefaultTimeout,
+		RetryPolicy: &policy,
+	})
+	c.setClock(clk)
+	c.setRand(seeded())
+
+	if _, err := c.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("TLS() error = %v", err)
+	}
+
+	want := seeded()
+	expected := []time.Duration{policy.backoff(0, want), policy.backoff(1, want)}
+	if got := clk.Waits(); !slices.Equal(got, expected) {
+		t.Errorf("waits = %v, want %v", got, expected)
+	}
+}
+
+func TestMonitorNextDelay(t *testing.T) {
+	t.Parallel()
+
+	c := NewClient()
+	c.setRand(seeded())
+	m := &Monitor{client: c, interval: time.Minute, jitter: 0.1}
+	want := seeded()
+
+	for _, elapsed := range []time.Duration{0, 10 * time.Second, 2 * time.Minute} {
+		jitter := time.Duration((want.Float64()*2 - 1) * 0.1 * float64(time.Minute))
+		expected := max(time.Minute+jitter-elapsed, 0)
+
+		if got := m.nextDelay(elapsed); got != expected {
+			t.Errorf("nextDelay(%s) = %s, want %s", elapsed, got, expected)
+		}
+	}
+
+	m.jitter = 0
+	if got := m.nextDelay(15 * time.Second); got != 45*time.Second {
+		t.Errorf("nextDelay() without jitter = %s, want 45s", got)
+	}
+}
+
+func TestHedgeUsesClock(t *testing.T) {
+	t.Parallel()
+
+	// The first request is held until the hedged one arrives, so only hedging can complete the call.
+	var requests atomic.Int32
+	hedged := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			select {
+			case <-hedged:
+			case <-r.Context().Done():
+				return
+			}
+		} else {
+			close(hedged)
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	clk := newFakeClock()
+	c := NewClientWithConfig(&Config{
+		Endpoint:   &Endpoint{BaseURL: srv.URL},
+		Timeout:    DefaultTimeout,
+		HedgeAfter: time.Hour,
+	})
+	c.setClock(clk)
+
+	if _, err := c.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("TLS() error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+	if got := clk.Waits(); !slices.Equal(got, []time.Duration{time.Hour}) {
+		t.Errorf("waits = %v, want the hedge delay of 1h", got)
+	}
+}
@@ -0,0 +1,136 @@
+package devsectools
+
+import "context"
+
+// FindDeprecatedTLS scans many hosts and reports which of them still support deprecated TLS versions.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - urls: The hosts to scan.
+//
+// Returns:
+//   - A map of URL to the deprecated versions it supports (e.g., `["TLS 1.0", "TLS 1.1"]`). Hosts with no
+//     deprecated versions are omitted.
+//   - The hosts that could not be scanned, with their errors. Empty if all scans succeeded.
+func (c *Client) FindDeprecatedTLS(ctx context.Context, urls []string) (map[string][]string, HostErrors) {
+	results, errs := c.scanTLS(ctx, urls)
+
+	deprecated := make(map[string][]string)
+	for url, result := range results {
+		versions := result.DeprecatedTLSVersions()
+		if len(versions) == 0 {
+			continue
+		}
+
+		names := make([]string, len(versions))
+		for i, v := range versions {
+			names[i] = v.String()
+		}
+		deprecated[url] = names
+	}
+
+	return deprecated, errs
+}
+
+// FailingHosts scans many hosts and reports those which fail a cipher policy.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - urls: The hosts to scan.
+//   - policy: The policy every host must satisfy.
+//
+// Returns:
+//   - A map of URL to the `PolicyResult` of every host which failed the policy. Hosts which passed are
+//     omitted, and so are hosts which could not be scanned.
+//   - A `HostErrors` listing the hosts which could not be scanned, or `nil` if all scans succeeded. These
+//     hosts were not checked, so they should not be treated as passing.
+func (c *Client) FailingHosts(
+	ctx context.Context,
+	urls []string,
+	policy CipherPolicy,
+) (map[string]PolicyResult, error) {
+	results, errs := c.scanTLS(ctx, urls)
+
+	failing := make(map[string]PolicyResult)
+	for url, result := range results {
+		if check := result.CheckPolicy(policy); !check.Passed {
+			failing[url] = check
+		}
+	}
+
+	if len(errs) > 0 {
+		return failing, errs
+	}
+
+	return failing, nil
+}
+
+// HTTPMatrix scans many hosts for the HTTP versions they support.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - urls: The hosts to scan.
+//
+// Returns:
+//   - A map of URL to `HttpResponse` for every successful scan.
+//   - The hosts that could not be scanned, with their errors. Empty if all scans succeeded.
+func (c *Client) HTTPMatrix(ctx context.Context, urls []string) (map[string]HttpResponse, HostErrors) {
+	requests := make([]BatchRequest, len(urls))
+	for i, url := range urls {
+		requests[i] = BatchRequest{Method: MethodHTTP, URL: url}
+	}
+	c.Batch(ctx, requests)
+
+	results, errs := CollectHTTP(requests)
+
+	matrix := make(map[string]HttpResponse, len(results))
+	for url, result := range results {
+		matrix[url] = *result
+	}
+
+	return matrix, HostErrors(errs)
+}
+
+// HTTP3AdoptionRate reports the fraction of hosts which support HTTP/3. Hosts which failed to scan should
+// not be present in `results`, and so don't count towards the total.
+//
+// Parameters:
+//   - results: The successful scans, as returned by `HTTPMatrix`.
+//
+// Returns:
+//   - A value between 0 and 1, or 0 if `results` is empty.
+func HTTP3AdoptionRate(results map[string]HttpResponse) float64 {
+	if len(results) == 0 {
+		return 0
+	}
+
+	supported := 0
+	for _, result := range results {
+		if result.HTTP3 {
+			supported++
+		}
+	}
+
+	return float64(supported) / float64(len(results))
+}
+
+// scanTLS runs a TLS batch over `urls`.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - urls: The hosts to scan.
+//
+// Returns:
+//   - A map of URL to `TlsResponse` for every successful scan.
+//   - The hosts that could not be scanned, with their errors.
+func (c *Client) scanTLS(ctx context.Context, urls []string) (map[string]*TlsResponse, HostErrors) {
+	requests := make([]BatchRequest, len(urls))
+	for i, url := range urls {
+		requests[i] = BatchRequest{Method: MethodTLS, URL: url}
+	}
+	c.Batch(ctx, requests)
+
+	results, errs := CollectTLS(requests)
+
+	return results, HostErrors(errs)
+}
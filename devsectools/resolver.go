@@ -0,0 +1,148 @@
+package devsectools
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// ConfigProvider supplies client settings from one source, such as the environment, a config file, or a
+// secrets manager. It returns the Options for the settings it knows about, and none for the rest, so that
+// providers with lower precedence can supply them.
+type ConfigProvider func() ([]Option, error)
+
+// ConfigResolver builds a client from a chain of ConfigProviders. Each setting is taken from the first
+// provider which sets it; settings which no provider sets keep the defaults of `NewClient`.
+//
+// Example, inserting a provider for a secrets manager between the environment and the config file:
+//
+//	resolver := &devsectools.ConfigResolver{
+//	    Providers: []devsectools.ConfigProvider{
+//	        devsectools.EnvProvider(),
+//	        func() ([]devsectools.Option, error) {
+//	            key, err := vault.Read("devsectools/api-key")
+//	            if err != nil {
+//	                return nil, err
+//	            }
+//	            return []devsectools.Option{devsectools.WithAPIKey(key)}, nil
+//	        },
+//	        devsectools.ProfileProvider("", ""),
+//	    },
+//	}
+//	client, err := resolver.NewClient()
+type ConfigResolver struct {
+	Providers []ConfigProvider // The providers, highest precedence first.
+}
+
+// DefaultConfigResolver returns the SDK's standard resolution order, highest precedence first:
+//
+//  1. `opts`, the explicit configuration.
+//  2. Environment variables (see `NewClientFromEnv`).
+//  3. The config file (see `NewClientWithProfile`), if it exists.
+//  4. The defaults of `NewClient`.
+//
+// Parameters:
+//   - opts: Optional Options, which take precedence over every other source.
+//
+// Returns:
+//   - A pointer to the resolver. Its `Providers` may be changed before use, e.g. to insert another source.
+func DefaultConfigResolver(opts ...Option) *ConfigResolver {
+	return &ConfigResolver{
+		Providers: []ConfigProvider{
+			OptionsProvider(opts...),
+			EnvProvider(),
+			ProfileProvider("", ""),
+		},
+	}
+}
+
+// Resolve collects the Options of every provider.
+//
+// Returns:
+//   - The Options, ordered so that applying them in turn gives each provider precedence over those after
+//     it.
+//   - The first error returned by a provider.
+func (r *ConfigResolver) Resolve() ([]Option, error) {
+	var resolved []Option
+
+	// Options applied later win, so apply the providers from lowest precedence to highest.
+	for i := len(r.Providers) - 1; i >= 0; i-- {
+		opts, err := r.Providers[i]()
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, opts...)
+	}
+
+	return resolved, nil
+}
+
+// NewClient initializes a new API client from the resolved settings.
+//
+// Returns:
+//   - A pointer to the newly created Client.
+//   - The first error returned by a provider.
+func (r *ConfigResolver) NewClient() (*Client, error) {
+	opts, err := r.Resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(opts...), nil
+}
+
+// OptionsProvider supplies fixed Options, typically the application's explicit configuration.
+//
+// Parameters:
+//   - opts: The Options to supply.
+//
+// Returns:
+//   - A ConfigProvider for a `ConfigResolver`.
+func OptionsProvider(opts ...Option) ConfigProvider {
+	return func() ([]Option, error) {
+		return opts, nil
+	}
+}
+
+// EnvProvider supplies the settings from the environment variables read by `NewClientFromEnv`.
+//
+// Returns:
+//   - A ConfigProvider for a `ConfigResolver`.
+func EnvProvider() ConfigProvider {
+	return func() ([]Option, error) {
+		return optionsFromEnv(os.Getenv)
+	}
+}
+
+// ProfileProvider supplies the settings from a profile in a config file, as read by `LoadProfile`. A
+// missing file supplies nothing, as does a missing profile unless it was named explicitly.
+//
+// Parameters:
+//   - path: The path of the config file. If empty, `DefaultConfigFile` is used.
+//   - profile: The name of the profile. If empty, `DEVSECTOOLS_PROFILE` is used, or else `DefaultProfile`.
+//
+// Returns:
+//   - A ConfigProvider for a `ConfigResolver`.
+func ProfileProvider(path, profile string) ConfigProvider {
+	return func() ([]Option, error) {
+		file := path
+		if file == "" {
+			var err error
+			if file, err = DefaultConfigFile(); err != nil {
+				return nil, err
+			}
+		}
+
+		named := profile != "" || os.Getenv(EnvProfile) != ""
+
+		opts, err := LoadProfile(file, profile)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return nil, nil
+		case errors.Is(err, ErrProfileNotFound) && !named:
+			return nil, nil
+		}
+
+		return opts, err
+	}
+}
@@ -0,0 +1,175 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. By default, network errors, `429 Too Many Requests`,
+// and `5xx` responses are retried; other errors are returned immediately.
+type RetryPolicy struct {
+	MaxRetries int           // Number of retries after the first attempt.
+	BaseDelay  time.Duration // Delay before the first retry, doubled for each subsequent retry.
+	MaxDelay   time.Duration // Upper bound on the delay between retries (0 = no bound).
+
+	// RetryableStatuses, if set, replaces the default set of retryable statuses (429 and 5xx). For
+	// example, `[]int{408, 502, 503, 504}` retries timeouts and gateway errors but not `429`. Network
+	// errors are still retried.
+	RetryableStatuses []int
+
+	// RetryIf, if set, decides whether a failed attempt is retried, replacing both the default rules and
+	// `RetryableStatuses`. It receives the response, with its body already read, or nil if none was
+	// received, and the attempt's error. Cancelled requests are never retried.
+	RetryIf func(resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy supplies the delays for `WithRetries` when the client has no `RetryPolicy`.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  200 * time.Millisecond,
+	MaxDelay:   5 * time.Second,
+}
+
+// Validate reports every problem with the policy's values.
+//
+// Returns:
+//   - An error describing each invalid value, or `nil` if the policy is valid.
+func (p RetryPolicy) Validate() error {
+	var errs []error
+
+	if p.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("retry policy: MaxRetries is negative (%d)", p.MaxRetries))
+	}
+
+	if p.MaxRetries > 0 && p.BaseDelay <= 0 {
+		errs = append(errs, fmt.Errorf("retry policy: BaseDelay must be positive, got %s", p.BaseDelay))
+	}
+
+	if p.MaxDelay != 0 && p.MaxDelay < p.BaseDelay {
+		errs = append(errs, fmt.Errorf(
+			"retry policy: MaxDelay (%s) is less than BaseDelay (%s)", p.MaxDelay, p.BaseDelay,
+		))
+	}
+
+	return errors.Join(errs...)
+}
+
+// normalized returns a copy of the policy with invalid values replaced by safe ones: a negative
+// `MaxRetries` becomes 0, a non-positive `BaseDelay` becomes `DefaultRetryPolicy.BaseDelay` (so retries
+// never hammer the server without backoff), and a `MaxDelay` below `BaseDelay` becomes `BaseDelay`.
+func (p RetryPolicy) normalized() RetryPolicy {
+	p.MaxRetries = max(p.MaxRetries, 0)
+
+	if p.MaxRetries > 0 && p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+
+	if p.MaxDelay != 0 && p.MaxDelay < p.BaseDelay {
+		p.MaxDelay = p.BaseDelay
+	}
+
+	return p
+}
+
+// retryPolicy resolves the retry policy for a call from the client configuration and per-call options. The
+// result is normalized, since a per-call retry count can enable retries on a policy which had none (and so
+// may have no `BaseDelay`).
+func (c *Client) retryPolicy(ro *requestOptions) RetryPolicy {
+	var policy RetryPolicy
+	if c.config.RetryPolicy != nil {
+		policy = *c.config.RetryPolicy
+	}
+
+	if ro.retries != nil {
+		if c.config.RetryPolicy == nil {
+			policy = DefaultRetryPolicy
+		}
+		policy.MaxRetries = *ro.retries
+	}
+
+	return policy.normalized()
+}
+
+// backoff calculates the delay before a retry: exponential in the attempt number, capped at `MaxDelay`,
+// with the upper half randomized so that concurrent clients don't retry in lockstep.
+//
+// Parameters:
+//   - attempt: The zero-based number of the attempt that just failed.
+//   - rnd: The source of randomness for the jitter.
+//
+// Returns:
+//   - The duration to wait before the next attempt.
+func (p RetryPolicy) backoff(attempt int, rnd randSource) time.Duration {
+	delay := p.BaseDelay
+	for range attempt {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if delay <= 1 {
+		return delay
+	}
+
+	half := delay / 2
+
+	return half + time.Duration(rnd.Int64N(int64(delay-half)))
+}
+
+// shouldRetry reports whether a failed attempt should be retried under the policy.
+//
+// Parameters:
+//   - resp: The response to the attempt, or `nil` if none was received.
+//   - err: The error of the attempt.
+//
+// Returns:
+//   - `true` if the attempt should be retried.
+func (p RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if p.RetryIf != nil {
+		return p.RetryIf(resp, err)
+	}
+
+	var apiErr *APIError
+	if p.RetryableStatuses != nil && errors.As(err, &apiErr) {
+		return slices.Contains(p.RetryableStatuses, apiErr.StatusCode)
+	}
+
+	return isRetryable(err)
+}
+
+// isRetryable reports whether a failed attempt is worth retrying under the default rules.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+
+	if errors.Is(err, ErrTooManyRedirects) {
+		return false
+	}
+
+	// Failures to send the request or receive the response, such as refused connections and timeouts,
+	// are usually transient. Other errors (e.g., an invalid URL) will fail the same way every time.
+	var urlErr *url.Error
+
+	return errors.As(err, &urlErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
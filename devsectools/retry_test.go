@@ -0,0 +1,205 @@
+package devsectools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		policy RetryPolicy
+		want   string // A substring of the error, or empty for a valid policy.
+	}{
+		"valid":                {RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: 5 * time.Second}, ""},
+		"zero value":           {RetryPolicy{}, ""},
+		"no retries, no delay": {RetryPolicy{MaxRetries: 0}, ""},
+		"negative retries":     {RetryPolicy{MaxRetries: -1, BaseDelay: time.Second}, "MaxRetries is negative"},
+		"zero base delay":      {RetryPolicy{MaxRetries: 3}, "BaseDelay must be positive"},
+		"negative base delay":  {RetryPolicy{MaxRetries: 3, BaseDelay: -time.Second}, "BaseDelay must be positive"},
+		"max below base": {
+			RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: time.Millisecond},
+			"MaxDelay",
+		},
+	}
+
+	for name, tt := range tests {
+		err := tt.policy.Validate()
+
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%s: Validate() error = %v, want nil", name, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("%s: Validate() error = %v, want one mentioning %q", name, err, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyNormalized(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		policy RetryPolicy
+		want   RetryPolicy
+	}{
+		"negative retries": {
+			RetryPolicy{MaxRetries: -2, BaseDelay: time.Second},
+			RetryPolicy{MaxRetries: 0, BaseDelay: time.Second},
+		},
+		"zero base delay": {
+			RetryPolicy{MaxRetries: 3},
+			RetryPolicy{MaxRetries: 3, BaseDelay: DefaultRetryPolicy.BaseDelay},
+		},
+		"max below base": {
+			RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: time.Millisecond},
+			RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: time.Second},
+		},
+	}
+
+	for name, tt := range tests {
+		got := tt.policy.normalized()
+		if got.MaxRetries != tt.want.MaxRetries || got.BaseDelay != tt.want.BaseDelay ||
+			got.MaxDelay != tt.want.MaxDelay {
+			t.Errorf("%s: normalized() = %+v, want %+v", name, got, tt.want)
+		}
+		if err := got.Validate(); err != nil {
+			t.Errorf("%s: normalized policy is invalid: %v", name, err)
+		}
+	}
+}
+
+func TestNewClientClampsRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	c := NewClientWithConfig(&Config{
+		Endpoint:    &PRODUCTION,
+		Timeout:     DefaultTimeout,
+		RetryPolicy: &RetryPolicy{MaxRetries: 3},
+		Logger:      slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+
+	if got := c.config.RetryPolicy.BaseDelay; got != DefaultRetryPolicy.BaseDelay {
+		t.Errorf("BaseDelay = %s, want %s", got, DefaultRetryPolicy.BaseDelay)
+	}
+	if !strings.Contains(logs.String(), "invalid retry policy") {
+		t.Errorf("log = %q, want a warning about the retry policy", logs.String())
+	}
+}
+
+func TestPerCallRetriesAreClamped(t *testing.T) {
+	t.Parallel()
+
+	c := NewClientWithConfig(&Config{Endpoint: &PRODUCTION, Timeout: DefaultTimeout, RetryPolicy: &RetryPolicy{}})
+
+	policy := c.retryPolicy(newRequestOptions([]RequestOption{WithRetries(5)}))
+	if policy.MaxRetries != 5 || policy.BaseDelay != DefaultRetryPolicy.BaseDelay {
+		t.Errorf("retryPolicy() = %+v, want 5 retries with a BaseDelay of %s", policy, DefaultRetryPolicy.BaseDelay)
+	}
+	if err := policy.Validate(); err != nil {
+		t.Errorf("retryPolicy() is invalid: %v", err)
+	}
+}
+
+// newStatusServer starts a test server which answers with each of `statuses` in turn, then with 200. It
+// returns the server and a counter of the requests received.
+func newStatusServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if n := int(requests.Add(1)); n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			_, _ = w.Write([]byte(`{"error": "try again"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestRetryableStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		statuses []int
+		status   int
+		want     int32 // Requests sent.
+	}{
+		"default retries 429":     {nil, http.StatusTooManyRequests, 2},
+		"default retries 503":     {nil, http.StatusServiceUnavailable, 2},
+		"default skips 408":       {nil, http.StatusRequestTimeout, 1},
+		"custom retries 408":      {[]int{408, 503}, http.StatusRequestTimeout, 2},
+		"custom skips 429":        {[]int{408, 503}, http.StatusTooManyRequests, 1},
+		"empty list retries none": {[]int{}, http.StatusServiceUnavailable, 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, requests := newStatusServer(t, tt.status)
+			c := NewClientWithConfig(&Config{
+				Endpoint: &Endpoint{BaseURL: srv.URL},
+				Timeout:  DefaultTimeout,
+				RetryPolicy: &RetryPolicy{
+					MaxRetries:        1,
+					BaseDelay:         time.Millisecond,
+					RetryableStatuses: tt.statuses,
+				},
+			})
+
+			_, _ = c.TLS(context.Background(), "example.com")
+			if got := requests.Load(); got != tt.want {
+				t.Errorf("server received %d requests, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryIf(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := newStatusServer(t, http.StatusConflict, http.StatusConflict, http.StatusBadRequest)
+
+	var seen []int
+	c := NewClientWithConfig(&Config{
+		Endpoint: &Endpoint{BaseURL: srv.URL},
+		Timeout:  DefaultTimeout,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries: 5,
+			BaseDelay:  time.Millisecond,
+			RetryIf: func(resp *http.Response, err error) bool {
+				seen = append(seen, resp.StatusCode)
+
+				var apiErr *APIError
+				return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+			},
+		},
+	})
+
+	_, err := c.TLS(context.Background(), "example.com")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("TLS() error = %v, want the 400 which RetryIf declined", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+	if len(seen) != 3 || seen[0] != 409 || seen[1] != 409 || seen[2] != 400 {
+		t.Errorf("RetryIf saw statuses %v, want [409 409 400]", seen)
+	}
+}
@@ -0,0 +1,163 @@
+package devsectools
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchWorkers is the number of workers started by `NewBatchRunner` when
+// `BatchOptions.MaxConcurrency` is not set.
+const DefaultBatchWorkers = 10
+
+// BatchRunner is a persistent pool of workers for running many batches without starting and stopping
+// goroutines for each one. Its rate limiter is shared across every `Run`, so a loop of small batches is
+// paced as one continuous stream. Create one with `Client.NewBatchRunner` and release it with `Close`.
+//
+// For one-off batches, `Client.Batch` and `Client.BatchWithOptions` are simpler.
+type BatchRunner struct {
+	client *Client
+	opts   BatchOptions
+	limits *batchLimits
+	jobs   chan batchJob
+
+	workers   sync.WaitGroup
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	statsMu sync.Mutex
+	stats   BatchStats
+}
+
+// batchJob is a single request handed to a worker.
+type batchJob struct {
+	ctx   context.Context
+	index int
+	req   *BatchRequest
+	done  *sync.WaitGroup
+}
+
+// NewBatchRunner starts a pool of workers bound to this client.
+//
+// The number of workers is `opts.MaxConcurrency`, or `DefaultBatchWorkers` if unset. Per-method limits and
+// the rate limit apply across all runs. Note that a worker waiting on a busy method's limit is not
+// available for other methods in the meantime.
+//
+// Parameters:
+//   - opts: A `BatchOptions` struct defining the concurrency and rate limits.
+//
+// Returns:
+//   - A pointer to the running BatchRunner.
+func (c *Client) NewBatchRunner(opts BatchOptions) *BatchRunner {
+	workers := opts.MaxConcurrency
+	if workers <= 0 {
+		workers = DefaultBatchWorkers
+	}
+
+	r := &BatchRunner{
+		client: c,
+		opts:   opts,
+		limits: c.newBatchLimits(opts, 0),
+		jobs:   make(chan batchJob),
+		closed: make(chan struct{}),
+	}
+
+	r.workers.Add(workers)
+	for range workers {
+		go r.work()
+	}
+
+	return r
+}
+
+// work processes jobs until the runner is closed.
+func (r *BatchRunner) work() {
+	defer r.workers.Done()
+
+	for {
+		select {
+		case job := <-r.jobs:
+			r.client.runBatchEntry(job.ctx, job.index, job.req, r.limits, r.opts)
+			job.done.Done()
+		case <-r.closed:
+			return
+		}
+	}
+}
+
+// Run executes a batch on the runner's workers and waits for it to complete. Results and per-request
+// errors are stored in each `BatchRequest`, exactly as with `Client.Batch`. Run may be called concurrently.
+//
+// Parameters:
+//   - ctx: A context to manage request timeouts and cancellations.
+//   - requests: A slice of `BatchRequest` structs defining the API calls.
+//
+// Returns:
+//   - A `*BatchValidationError` if `BatchOptions.ValidateFirst` is set and any request is invalid.
+//   - `ErrBatchRunnerClosed` if the runner was closed before every request was dispatched. Requests which
+//     were not dispatched have their `Err` set to the same error.
+func (r *BatchRunner) Run(ctx context.Context, requests []BatchRequest) error {
+	if r.opts.ValidateFirst {
+		if err := ValidateBatch(requests); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := r.opts.withDeadline(ctx)
+	defer cancel()
+
+	var (
+		done    sync.WaitGroup
+		runErr  error
+		started = r.client.clock.Now()
+	)
+
+dispatch:
+	for i := range requests {
+		if err := contextErr(ctx); err != nil {
+			markBatchErr(requests[i:], err)
+			break
+		}
+
+		done.Add(1)
+		select {
+		case r.jobs <- batchJob{ctx: ctx, index: i, req: &requests[i], done: &done}:
+		case <-ctx.Done():
+			done.Done()
+			markBatchErr(requests[i:], contextErr(ctx))
+			break dispatch
+		case <-r.closed:
+			done.Done()
+			markBatchErr(requests[i:], ErrBatchRunnerClosed)
+			runErr = ErrBatchRunnerClosed
+			break dispatch
+		}
+	}
+	done.Wait()
+
+	r.statsMu.Lock()
+	r.stats.add(requests)
+	r.stats.Duration += r.client.clock.Now().Sub(started)
+	r.statsMu.Unlock()
+
+	return runErr
+}
+
+// Stats returns a summary of every request run since the runner was created, including requests which
+// were never dispatched because the context ended or the runner closed. It is safe to call during `Run`.
+//
+// Returns:
+//   - A snapshot of the runner's statistics.
+func (r *BatchRunner) Stats() BatchStats {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+
+	return r.stats.clone()
+}
+
+// Close stops the workers after their current requests complete. It is safe to call more than once.
+func (r *BatchRunner) Close() {
+	r.closeOnce.Do(func() {
+		close(r.closed)
+	})
+	r.workers.Wait()
+}
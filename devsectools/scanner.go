@@ -0,0 +1,71 @@
+package devsectools
+
+import "context"
+
+// Scanner is the set of scan methods provided by a `Client`. Accept it instead of `*Client` in code which
+// only needs to run scans.
+type Scanner interface {
+	// Domain retrieves the parsed domain information from the API. See `Client.Domain`.
+	Domain(ctx context.Context, url string, opts ...RequestOption) (*DomainResponse, error)
+
+	// HTTP retrieves HTTP protocol support information from the API. See `Client.HTTP`.
+	HTTP(ctx context.Context, url string, opts ...RequestOption) (*HttpResponse, error)
+
+	// TLS retrieves TLS protocol support information from the API. See `Client.TLS`.
+	TLS(ctx context.Context, url string, opts ...RequestOption) (*TlsResponse, error)
+
+	// ScanAll runs the domain, HTTP, and TLS scans of a host concurrently. See `Client.ScanAll`.
+	ScanAll(ctx context.Context, url string, opts ...RequestOption) (*FullScan, error)
+}
+
+// API is the set of API methods provided by a `Client`: the scans of `Scanner`, and batches of them. Accept
+// it instead of `*Client` so that tests can substitute a fake implementation, without an HTTP server.
+type API interface {
+	Scanner
+
+	// Batch executes multiple API requests concurrently. See `Client.Batch`.
+	Batch(ctx context.Context, requests []BatchRequest)
+
+	// BatchWithOptions executes multiple API requests concurrently, honoring concurrency and rate limits.
+	// See `Client.BatchWithOptions`.
+	BatchWithOptions(ctx context.Context, requests []BatchRequest, opts BatchOptions) error
+}
+
+var (
+	_ Scanner = (*Client)(nil)
+	_ API     = (*Client)(nil)
+)
+
+// readOnlyClient exposes the scan methods of a Client and nothing else.
+type readOnlyClient struct {
+	client *Client
+}
+
+// ReadOnly returns a view of the client which can run scans but can't change its configuration (e.g., with
+// `SetTimeout` or `SetBaseURL`), or close it. Hand it to subsystems which shouldn't be able to affect other
+// users of the client.
+//
+// The view shares everything else with the client: its connections, cache, and event subscribers.
+// Configuration changes made through the client, and closing it, are seen by the view.
+//
+// Returns:
+//   - A `Scanner` backed by the client.
+func (c *Client) ReadOnly() Scanner {
+	return readOnlyClient{client: c}
+}
+
+func (r readOnlyClient) Domain(ctx context.Context, url string, opts ...RequestOption) (*DomainResponse, error) {
+	return r.client.Domain(ctx, url, opts...)
+}
+
+func (r readOnlyClient) HTTP(ctx context.Context, url string, opts ...RequestOption) (*HttpResponse, error) {
+	return r.client.HTTP(ctx, url, opts...)
+}
+
+func (r readOnlyClient) TLS(ctx context.Context, url string, opts ...RequestOption) (*TlsResponse, error) {
+	return r.client.TLS(ctx, url, opts...)
+}
+
+func (r readOnlyClient) ScanAll(ctx context.Context, url string, opts ...RequestOption) (*FullScan, error) {
+	return r.client.ScanAll(ctx, url, opts...)
+}
@@ -0,0 +1,104 @@
+package devsectools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingServer records the method, path, and scan target of every request it receives.
+type recordingServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func newRecordingServer(t *testing.T) *recordingServer {
+	t.Helper()
+
+	rs := &recordingServer{}
+	rs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rs.mu.Lock()
+		rs.requests = append(rs.requests, r.Method+" "+r.URL.Path+" "+r.URL.Query().Get("url"))
+		rs.mu.Unlock()
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(rs.Close)
+
+	return rs
+}
+
+func (rs *recordingServer) received() []string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	return append([]string(nil), rs.requests...)
+}
+
+// newTLDClient returns a client which sends ".de" targets to `eu` and everything else to `us`.
+func newTLDClient(eu, us *recordingServer) *Client {
+	return NewClientWithConfig(&Config{
+		Endpoint: &Endpoint{BaseURL: us.URL},
+		Timeout:  DefaultTimeout,
+		EndpointSelector: func(target string) *Endpoint {
+			if strings.HasSuffix(target, ".de") {
+				return &Endpoint{BaseURL: eu.URL}
+			}
+
+			return nil
+		},
+	})
+}
+
+func TestEndpointSelectorRoutesByTLD(t *testing.T) {
+	t.Parallel()
+
+	eu, us := newRecordingServer(t), newRecordingServer(t)
+	c := newTLDClient(eu, us)
+
+	for _, target := range []string{"example.de", "example.com"} {
+		if _, err := c.TLS(context.Background(), target); err != nil {
+			t.Fatalf("TLS(%q) error = %v", target, err)
+		}
+
+		if err := c.TLSStream(context.Background(), target, func(CipherSuite) error { return nil }); err != nil {
+			t.Fatalf("TLSStream(%q) error = %v", target, err)
+		}
+	}
+
+	assertRequests(t, "eu", eu.received(), "GET /tls example.de", "GET /tls example.de")
+	assertRequests(t, "us", us.received(), "GET /tls example.com", "GET /tls example.com")
+}
+
+func TestWarmupUsesEndpointSelector(t *testing.T) {
+	t.Parallel()
+
+	eu, us := newRecordingServer(t), newRecordingServer(t)
+	c := newTLDClient(eu, us)
+
+	if err := c.Warmup(context.Background(), 2, "a.example.de", "b.example.de"); err != nil {
+		t.Fatalf("Warmup() error = %v", err)
+	}
+
+	assertRequests(t, "eu", eu.received(), "HEAD / ", "HEAD / ")
+	assertRequests(t, "us", us.received())
+
+	if err := c.Warmup(context.Background(), 1); err != nil {
+		t.Fatalf("Warmup() error = %v", err)
+	}
+
+	assertRequests(t, "us", us.received(), "HEAD / ")
+}
+
+func assertRequests(t *testing.T, name string, got []string, want ...string) {
+	t.Helper()
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("%s server received %q, want %q", name, got, want)
+	}
+}
@@ -0,0 +1,120 @@
+package devsectools
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// expectedSignature recomputes the `X-Signature` header of a request signed by an HMACSigner from the
+// request's method, URI, `Date` header, and body, independently of `HMACSigner.Sign`.
+func expectedSignature(t *testing.T, keyID string, secret []byte, method, uri, date string, body []byte) string {
+	t.Helper()
+
+	digest := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(method + "\n" + uri + "\n" + date + "\n" + hex.EncodeToString(digest[:])))
+
+	return `keyId="` + keyID + `",algorithm="hmac-sha256",signature="` +
+		base64.StdEncoding.EncodeToString(mac.Sum(nil)) + `"`
+}
+
+func TestHMACSignerSign(t *testing.T) {
+	t.Parallel()
+
+	clk := newFakeClock()
+	signer := &HMACSigner{KeyID: "key-1", Secret: []byte("s3cret"), clock: clk}
+	body := []byte(`{"urls": ["example.com"]}`)
+
+	req := httptest.NewRequest(http.MethodPost, "https://api.example.com/tls?url=example.com", nil)
+	req.Method = "post"
+	if err := signer.Sign(req, body); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	const date = "Wed, 01 Jan 2025 00:00:00 GMT"
+	if got := req.Header.Get("Date"); got != date {
+		t.Errorf("Date = %q, want %q from the signer's clock", got, date)
+	}
+
+	digest := sha256.Sum256(body)
+	if got, want := req.Header.Get(HeaderContentSHA256), hex.EncodeToString(digest[:]); got != want {
+		t.Errorf("%s = %q, want %q", HeaderContentSHA256, got, want)
+	}
+
+	want := expectedSignature(t, "key-1", []byte("s3cret"), "POST", "/tls?url=example.com", date, body)
+	if got := req.Header.Get(HeaderSignature); got != want {
+		t.Errorf("%s = %q, want %q", HeaderSignature, got, want)
+	}
+}
+
+func TestHMACSignerWithoutSecret(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "https://api.example.com/tls", nil)
+	if err := (&HMACSigner{KeyID: "key-1"}).Sign(req, nil); err == nil {
+		t.Error("Sign() error = nil, want an error for a missing secret")
+	}
+}
+
+func TestSignerResignsRetries(t *testing.T) {
+	t.Parallel()
+
+	type signed struct {
+		uri, date, signature string
+	}
+
+	var (
+		mu       sync.Mutex
+		requests []signed
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, signed{r.URL.RequestURI(), r.Header.Get("Date"), r.Header.Get(HeaderSignature)})
+		n := len(requests)
+		mu.Unlock()
+
+		if n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	secret := []byte("s3cret")
+	c := NewClientWithConfig(&Config{
+		Endpoint:    &Endpoint{BaseURL: srv.URL},
+		Timeout:     DefaultTimeout,
+		Signer:      &HMACSigner{KeyID: "key-1", Secret: secret},
+		RetryPolicy: &RetryPolicy{MaxRetries: 1, BaseDelay: time.Minute},
+	})
+	c.setClock(newFakeClock())
+
+	if _, err := c.TLS(context.Background(), "example.com"); err != nil {
+		t.Fatalf("TLS() error = %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("server received %d requests, want 2", len(requests))
+	}
+	if requests[0].date == requests[1].date || requests[0].signature == requests[1].signature {
+		t.Errorf("retry reused the signature of the first attempt: %+v", requests)
+	}
+	for i, r := range requests {
+		if !strings.HasPrefix(r.uri, "/tls?") {
+			t.Errorf("request %d URI = %q, want the TLS endpoint", i, r.uri)
+		}
+		if want := expectedSignature(t, "key-1", secret, http.MethodGet, r.uri, r.date, nil); r.signature != want {
+			t.Errorf("request %d %s = %q, want %q", i, HeaderSignature, r.signature, want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package devsectools
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// BatchStats summarizes the outcome of batch requests.
+type BatchStats struct {
+	Total     int                        // Number of requests.
+	Succeeded int                        // Number of requests which succeeded.
+	Failed    int                        // Number of requests which failed.
+	CacheHits int                        // Number of requests answered from the cache.
+	Duration  time.Duration              // Total wall-clock time spent running batches.
+	ByError   map[string]int             // Failures by kind (e.g., "timeout", "status 429", "canceled").
+	ByMethod  map[ScanMethod]MethodStats // Counts per method, e.g. `ByMethod[MethodTLS]`.
+}
+
+// MethodStats counts the requests of a single method within BatchStats.
+type MethodStats struct {
+	Total     int // Number of requests.
+	Succeeded int // Number of requests which succeeded.
+	Failed    int // Number of requests which failed.
+}
+
+// String returns a one-line summary, e.g. "scanned 480/500, 20 failed (12 timeout, 8 status 429)".
+func (s BatchStats) String() string {
+	summary := fmt.Sprintf("scanned %d/%d, %d failed", s.Succeeded, s.Total, s.Failed)
+	if len(s.ByError) == 0 {
+		return summary
+	}
+
+	// Most frequent first, then alphabetically for a stable order.
+	kinds := slices.SortedFunc(maps.Keys(s.ByError), func(a, b string) int {
+		if n := cmp.Compare(s.ByError[b], s.ByError[a]); n != 0 {
+			return n
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	parts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		parts[i] = fmt.Sprintf("%d %s", s.ByError[kind], kind)
+	}
+
+	return fmt.Sprintf("%s (%s)", summary, strings.Join(parts, ", "))
+}
+
+// add records the outcome of completed requests.
+func (s *BatchStats) add(requests []BatchRequest) {
+	if s.ByError == nil {
+		s.ByError = make(map[string]int)
+	}
+	if s.ByMethod == nil {
+		s.ByMethod = make(map[ScanMethod]MethodStats)
+	}
+
+	for i := range requests {
+		req := &requests[i]
+		method := s.ByMethod[req.Method.canonical()]
+
+		s.Total++
+		method.Total++
+
+		if req.Cached {
+			s.CacheHits++
+		}
+
+		if req.Err != nil {
+			s.Failed++
+			method.Failed++
+			s.ByError[errorKind(req.Err)]++
+		} else {
+			s.Succeeded++
+			method.Succeeded++
+		}
+
+		s.ByMethod[req.Method.canonical()] = method
+	}
+}
+
+// clone returns a deep copy of the stats.
+func (s BatchStats) clone() BatchStats {
+	s.ByError = maps.Clone(s.ByError)
+	s.ByMethod = maps.Clone(s.ByMethod)
+
+	return s
+}
+
+// errorKind classifies an error for statistics.
+//
+// Parameters:
+//   - err: The error to classify.
+//
+// Returns:
+//   - A short description of the kind of error (e.g., "timeout" or "status 429").
+func errorKind(err error) string {
+	var apiErr *APIError
+
+	switch {
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &apiErr):
+		return fmt.Sprintf("status %d", apiErr.StatusCode)
+	case errors.Is(err, ErrPanic):
+		return "panic"
+	default:
+		return "other"
+	}
+}
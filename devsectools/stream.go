@@ -0,0 +1,166 @@
+package devsectools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// TLSStream retrieves TLS information like `TLS`, but decodes the cipher suites one at a time as the
+// response is read, passing each to `fn`, instead of holding the whole response in memory. It is meant
+// for memory-constrained environments scanning hosts which offer many cipher suites.
+//
+// The request is sent like any other (events, statistics, authentication, `Config.MinRequestTime`, and
+// `Config.ResponseInspector` all apply), but only once: retries, hedging, and the cache don't apply, and
+// neither does `Config.MaxResponseBytes`, since the body is never held in memory. With `Config.Offline`,
+// it fails with `ErrOffline`.
+//
+// Parameters:
+//   - ctx: Context for handling timeouts and cancellations.
+//   - url: The domain to scan (e.g., "example.com").
+//   - fn: Called with each cipher suite, in response order. Returning an error stops decoding.
+//   - opts: Optional RequestOptions which customize this call.
+//
+// Returns:
+//   - The error returned by `fn`, if any, unwrapped.
+//   - Otherwise, an error if the request fails, the API returns an error, or the response is malformed.
+func (c *Client) TLSStream(
+	ctx context.Context,
+	url string,
+	fn func(CipherSuite) error,
+	opts ...RequestOption,
+) error {
+	if c.closed.Load() {
+		return ErrClientClosed
+	}
+	if c.config.Offline {
+		return ErrOffline
+	}
+
+	target, err := c.normalizeTarget(url)
+	if err != nil {
+		return err
+	}
+
+	// The callback's error is kept aside so that it can be returned exactly as `fn` returned it.
+	var fnErr error
+	ro := newRequestOptions(opts)
+	ro.query = mergeQuery(scanQuery(target), ro.query)
+	ro.stream = func(body io.Reader) error {
+		return streamCipherSuites(json.NewDecoder(body), func(suite CipherSuite) error {
+			fnErr = fn(suite)
+			return fnErr
+		})
+	}
+	c.selectEndpoint(ro)
+
+	return c.observe(ctx, Event{Endpoint: "/tls", URL: target}, func(ctx context.Context) error {
+		err := c.checkTimeBudget(ctx)
+		if err == nil {
+			_, _, err = c.authenticatedAttempt(ctx, http.MethodGet, "/tls", nil, ro)
+		}
+
+		if fnErr != nil {
+			return fnErr
+		}
+
+		return classifyError(err)
+	})
+}
+
+// streamCipherSuites walks a `TlsResponse` document, decoding each entry of `tlsConnections[].cipherSuites`
+// individually and skipping everything else.
+//
+// Parameters:
+//   - dec: A decoder positioned at the start of the document.
+//   - fn: Called with each cipher suite. Returning an error stops decoding.
+//
+// Returns:
+//   - The error returned by `fn`, or an error if the document is malformed.
+func streamCipherSuites(dec *json.Decoder, fn func(CipherSuite) error) error {
+	return decodeObject(dec, func(key string) error {
+		if key != "tlsConnections" {
+			return skipValue(dec)
+		}
+
+		return decodeArray(dec, func() error {
+			return decodeObject(dec, func(key string) error {
+				if key != "cipherSuites" {
+					return skipValue(dec)
+				}
+
+				return decodeArray(dec, func() error {
+					var suite CipherSuite
+					if err := dec.Decode(&suite); err != nil {
+						return err
+					}
+
+					return fn(suite)
+				})
+			})
+		})
+	})
+}
+
+// decodeObject reads a JSON object, calling `field` with each key while the decoder is positioned at the
+// key's value. `field` must consume the value. A JSON `null` is treated as an empty object.
+func decodeObject(dec *json.Decoder, field func(key string) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("devsectools: expected JSON object, got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if err := field(tok.(string)); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token() // The closing '}'.
+
+	return err
+}
+
+// decodeArray reads a JSON array, calling `elem` while the decoder is positioned at each element. `elem`
+// must consume the element. A JSON `null` is treated as an empty array.
+func decodeArray(dec *json.Decoder, elem func() error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("devsectools: expected JSON array, got %v", tok)
+	}
+
+	for dec.More() {
+		if err := elem(); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token() // The closing ']'.
+
+	return err
+}
+
+// skipValue consumes the next JSON value without decoding it.
+func skipValue(dec *json.Decoder) error {
+	var skipped json.RawMessage
+	return dec.Decode(&skipped)
+}
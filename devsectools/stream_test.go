@@ -0,0 +1,180 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const streamBody = `{
+	"hostname": "example.com",
+	"tlsConnections": [
+		{"version": "TLS 1.3", "cipherSuites": [
+			{"ianaName": "TLS_AES_128_GCM_SHA256"},
+			{"ianaName": "TLS_AES_256_GCM_SHA384"}
+		]},
+		{"version": "TLS 1.2", "cipherSuites": null},
+		{"version": "TLS 1.2", "cipherSuites": [{"ianaName": "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}]}
+	]
+}`
+
+// newStreamServer starts a test server which answers `/tls` with `streamBody`.
+func newStreamServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tls" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(streamBody))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestTLSStream(t *testing.T) {
+	t.Parallel()
+
+	srv := newStreamServer(t)
+
+	var inspected atomic.Int32
+	c := NewClientWithConfig(&Config{
+		Endpoint:          &Endpoint{BaseURL: srv.URL},
+		Timeout:           DefaultTimeout,
+		ResponseInspector: func(*http.Response) { inspected.Add(1) },
+	})
+
+	var got []string
+	err := c.TLSStream(context.Background(), "example.com", func(suite CipherSuite) error {
+		got = append(got, suite.IANAName)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("TLSStream() error = %v", err)
+	}
+
+	want := []string{
+		"TLS_AES_128_GCM_SHA256",
+		"TLS_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("TLSStream() passed %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("suite %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if n := inspected.Load(); n != 1 {
+		t.Errorf("ResponseInspector called %d times, want 1", n)
+	}
+	if stats := c.Stats(); stats.Requests != 1 || stats.Succeeded != 1 {
+		t.Errorf("Stats() = %+v, want 1 request, 1 succeeded", stats)
+	}
+}
+
+func TestTLSStreamStopsOnCallbackError(t *testing.T) {
+	t.Parallel()
+
+	srv := newStreamServer(t)
+	c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+
+	stop := errors.New("stop")
+	calls := 0
+	err := c.TLSStream(context.Background(), "example.com", func(CipherSuite) error {
+		calls++
+		return stop
+	})
+	if err != stop { //nolint:errorlint // The callback's error must be returned unwrapped.
+		t.Errorf("TLSStream() error = %v, want the callback's error", err)
+	}
+	if calls != 1 {
+		t.Errorf("callback called %d times, want 1", calls)
+	}
+	if stats := c.Stats(); stats.Failed != 1 {
+		t.Errorf("Stats().Failed = %d, want 1", stats.Failed)
+	}
+}
+
+func TestTLSStreamOffline(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		requests.Add(1)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClientWithConfig(&Config{Endpoint: &Endpoint{BaseURL: srv.URL}, Timeout: DefaultTimeout, Offline: true})
+
+	err := c.TLSStream(context.Background(), "example.com", func(CipherSuite) error { return nil })
+	if !errors.Is(err, ErrOffline) {
+		t.Errorf("TLSStream() error = %v, want ErrOffline", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("server received %d requests in offline mode", n)
+	}
+}
+
+func TestTLSStreamMinRequestTime(t *testing.T) {
+	t.Parallel()
+
+	srv := newStreamServer(t)
+	c := NewClientWithConfig(&Config{
+		Endpoint:       &Endpoint{BaseURL: srv.URL},
+		Timeout:        DefaultTimeout,
+		MinRequestTime: time.Minute,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := c.TLSStream(ctx, "example.com", func(CipherSuite) error { return nil })
+	if !errors.Is(err, ErrInsufficientTime) {
+		t.Errorf("TLSStream() error = %v, want ErrInsufficientTime", err)
+	}
+}
+
+func TestTLSStreamRefreshesToken(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "unauthorized"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(streamBody))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClientWithConfig(&Config{
+		Endpoint: &Endpoint{BaseURL: srv.URL},
+		Timeout:  DefaultTimeout,
+		Authenticator: NewAuthenticator("stale", func(context.Context) (string, error) {
+			return "fresh", nil
+		}),
+	})
+
+	calls := 0
+	err := c.TLSStream(context.Background(), "example.com", func(CipherSuite) error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("TLSStream() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("callback called %d times, want 3", calls)
+	}
+}
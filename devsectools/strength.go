@@ -0,0 +1,123 @@
+package devsectools
+
+import "strings"
+
+// CipherStrength ranks the `Strength` reported for a cipher suite, so that suites can be compared. Higher
+// values are stronger.
+type CipherStrength int
+
+// Cipher suite strengths reported by the API, weakest first.
+const (
+	StrengthUnknown     CipherStrength = iota // The API reported no strength, or one the SDK doesn't know.
+	StrengthInsecure                          // "insecure": broken, must not be used.
+	StrengthWeak                              // "weak": usable, but has known weaknesses.
+	StrengthSecure                            // "secure": no known weaknesses.
+	StrengthRecommended                       // "recommended": secure and preferred for new deployments.
+)
+
+// String returns the name the API uses for the strength (e.g., "recommended").
+func (s CipherStrength) String() string {
+	switch s {
+	case StrengthInsecure:
+		return "insecure"
+	case StrengthWeak:
+		return "weak"
+	case StrengthSecure:
+		return "secure"
+	case StrengthRecommended:
+		return "recommended"
+	default:
+		return "unknown"
+	}
+}
+
+// Rank parses the suite's `Strength` field. The comparison is case-insensitive.
+//
+// Returns:
+//   - The strength of the cipher suite, or `StrengthUnknown` if it isn't recognized.
+func (s CipherSuite) Rank() CipherStrength {
+	switch strings.ToLower(s.Strength) {
+	case "insecure":
+		return StrengthInsecure
+	case "weak":
+		return StrengthWeak
+	case "secure":
+		return StrengthSecure
+	case "recommended":
+		return StrengthRecommended
+	default:
+		return StrengthUnknown
+	}
+}
+
+// VersionStrength describes the strongest cipher suite offered under one TLS version.
+type VersionStrength struct {
+	Version     string         // The TLS version, as reported by the API (e.g., "TLS 1.2").
+	VersionID   TLSVersion     // The TLS version's wire value.
+	Strongest   CipherStrength // The strength of the strongest suite offered under this version.
+	CipherSuite string         // The IANA name of that suite.
+}
+
+// StrengthByVersion reports the strongest cipher suite offered under each TLS version. Versions which
+// offered no suites of known strength are omitted.
+//
+// Returns:
+//   - One entry per TLS connection, in response order.
+func (r *TlsResponse) StrengthByVersion() []VersionStrength {
+	var strengths []VersionStrength
+	for _, conn := range r.connections() {
+		best := VersionStrength{Version: conn.Version, VersionID: TLSVersion(conn.VersionID)}
+		for _, suite := range conn.CipherSuites {
+			if rank := suite.Rank(); rank > best.Strongest {
+				best.Strongest = rank
+				best.CipherSuite = suite.IANAName
+			}
+		}
+
+		if best.Strongest != StrengthUnknown {
+			strengths = append(strengths, best)
+		}
+	}
+
+	return strengths
+}
+
+// InconsistentStrength reports whether some enabled TLS versions offer secure cipher suites while others
+// offer only weak or insecure ones. See `StrengthInconsistency` for which versions differ.
+//
+// Returns:
+//   - `true` if the strongest available cipher suite differs significantly between TLS versions.
+func (r *TlsResponse) InconsistentStrength() bool {
+	_, _, inconsistent := r.StrengthInconsistency()
+	return inconsistent
+}
+
+// StrengthInconsistency finds the TLS versions with the weakest and strongest best-available cipher suites.
+// The difference is significant when the strongest version reaches `StrengthSecure` but the weakest
+// doesn't, meaning a client negotiating the weak version can't get a secure cipher suite at all.
+//
+// Returns:
+//   - The version whose strongest cipher suite is the weakest: the weak link.
+//   - The version whose strongest cipher suite is the strongest.
+//   - Whether the difference between them is significant. If `false`, both versions are zero values
+//     unless the response offers at least two versions with known strengths.
+func (r *TlsResponse) StrengthInconsistency() (weakest, strongest VersionStrength, inconsistent bool) {
+	strengths := r.StrengthByVersion()
+	if len(strengths) < 2 {
+		return VersionStrength{}, VersionStrength{}, false
+	}
+
+	weakest, strongest = strengths[0], strengths[0]
+	for _, s := range strengths[1:] {
+		if s.Strongest < weakest.Strongest {
+			weakest = s
+		}
+		if s.Strongest > strongest.Strongest {
+			strongest = s
+		}
+	}
+
+	inconsistent = strongest.Strongest >= StrengthSecure && weakest.Strongest < StrengthSecure
+
+	return weakest, strongest, inconsistent
+}
@@ -0,0 +1,212 @@
+package devsectools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TLSVersion identifies a TLS protocol version by its wire value, matching `TlsConnection.VersionID`.
+type TLSVersion uint16
+
+// TLS protocol versions reported by the API.
+const (
+	VersionTLS10 TLSVersion = 0x0301
+	VersionTLS11 TLSVersion = 0x0302
+	VersionTLS12 TLSVersion = 0x0303
+	VersionTLS13 TLSVersion = 0x0304
+)
+
+// String returns the conventional name of the version (e.g., "TLS 1.3").
+func (v TLSVersion) String() string {
+	switch v {
+	case VersionTLS10:
+		return "TLS 1.0"
+	case VersionTLS11:
+		return "TLS 1.1"
+	case VersionTLS12:
+		return "TLS 1.2"
+	case VersionTLS13:
+		return "TLS 1.3"
+	default:
+		return fmt.Sprintf("TLS 0x%04x", uint16(v))
+	}
+}
+
+// ParseTLSVersion parses a TLS version name. It accepts the form produced by `TLSVersion.String` (e.g.,
+// "TLS 1.2"), with or without the space, and the bare number (e.g., "1.2"). The comparison is
+// case-insensitive.
+//
+// Parameters:
+//   - name: The version name.
+//
+// Returns:
+//   - The TLS version.
+//   - An error if the name isn't a known TLS version.
+func ParseTLSVersion(name string) (TLSVersion, error) {
+	number := strings.TrimSpace(strings.ToLower(name))
+	number = strings.TrimSpace(strings.TrimPrefix(number, "tls"))
+
+	switch number {
+	case "1.0":
+		return VersionTLS10, nil
+	case "1.1":
+		return VersionTLS11, nil
+	case "1.2":
+		return VersionTLS12, nil
+	case "1.3":
+		return VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("devsectools: unknown TLS version %q", name)
+	}
+}
+
+// connections returns the scan's TLS connections. Like the other helpers on TlsResponse, it is safe to call
+// on a nil response, which is treated as a host without TLS.
+func (r *TlsResponse) connections() []TlsConnection {
+	if r == nil {
+		return nil
+	}
+
+	return r.TLSConn
+}
+
+// EnabledVersions lists the TLS versions the host supports, from oldest to newest.
+//
+// Returns:
+//   - The enabled TLS versions. Empty if the host doesn't support TLS, or `r` is nil.
+func (r *TlsResponse) EnabledVersions() []TLSVersion {
+	if r == nil {
+		return nil
+	}
+
+	var versions []TLSVersion
+	for _, v := range []struct {
+		enabled bool
+		version TLSVersion
+	}{
+		{r.TLSVersions.TLS10, VersionTLS10},
+		{r.TLSVersions.TLS11, VersionTLS11},
+		{r.TLSVersions.TLS12, VersionTLS12},
+		{r.TLSVersions.TLS13, VersionTLS13},
+	} {
+		if v.enabled {
+			versions = append(versions, v.version)
+		}
+	}
+
+	return versions
+}
+
+// HighestTLSVersion returns the newest TLS version the host supports.
+//
+// Returns:
+//   - The newest enabled TLS version, or zero if the host doesn't support TLS.
+func (r *TlsResponse) HighestTLSVersion() TLSVersion {
+	versions := r.EnabledVersions()
+	if len(versions) == 0 {
+		return 0
+	}
+
+	return versions[len(versions)-1]
+}
+
+// DeprecatedTLSVersions lists the deprecated TLS versions (TLS 1.0 and TLS 1.1, per RFC 8996) that the host
+// supports.
+//
+// Returns:
+//   - The enabled deprecated versions, oldest first. Empty if there are none.
+func (r *TlsResponse) DeprecatedTLSVersions() []TLSVersion {
+	var deprecated []TLSVersion
+	for _, v := range r.EnabledVersions() {
+		if v < VersionTLS12 {
+			deprecated = append(deprecated, v)
+		}
+	}
+
+	return deprecated
+}
+
+// SupportsDeprecatedTLS reports whether the host supports TLS 1.0 or TLS 1.1.
+//
+// Returns:
+//   - `true` if any deprecated TLS version is enabled.
+func (r *TlsResponse) SupportsDeprecatedTLS() bool {
+	return len(r.DeprecatedTLSVersions()) > 0
+}
+
+// FindCipherSuite looks up a cipher suite by its IANA name (e.g., "TLS_AES_256_GCM_SHA384") across all
+// TLS connections in the response. The comparison is case-insensitive.
+//
+// Parameters:
+//   - ianaName: The IANA name of the cipher suite.
+//
+// Returns:
+//   - A pointer to the first matching `CipherSuite`, or `nil` if there is no match.
+//   - Whether a match was found.
+func (r *TlsResponse) FindCipherSuite(ianaName string) (*CipherSuite, bool) {
+	conns := r.connections()
+	for i := range conns {
+		suites := conns[i].CipherSuites
+		for j := range suites {
+			if strings.EqualFold(suites[j].IANAName, ianaName) {
+				return &suites[j], true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// CipherSuiteVersions lists the TLS versions under which a cipher suite was offered. The comparison is
+// case-insensitive.
+//
+// Parameters:
+//   - ianaName: The IANA name of the cipher suite (e.g., "TLS_AES_256_GCM_SHA384").
+//
+// Returns:
+//   - The `Version` of every TLS connection offering the cipher suite, in response order. Empty if none.
+func (r *TlsResponse) CipherSuiteVersions(ianaName string) []string {
+	var versions []string
+	for _, conn := range r.connections() {
+		for _, suite := range conn.CipherSuites {
+			if strings.EqualFold(suite.IANAName, ianaName) {
+				versions = append(versions, conn.Version)
+				break
+			}
+		}
+	}
+
+	return versions
+}
+
+// RequiresCipher reports whether the host offers a cipher suite, under any of its enabled TLS versions. It
+// is a convenience for gating checks (e.g., "the host must offer TLS_AES_256_GCM_SHA384"). The comparison
+// is case-insensitive.
+//
+// Parameters:
+//   - ianaName: The IANA name of the cipher suite.
+//
+// Returns:
+//   - `true` if the cipher suite was offered under at least one TLS version.
+func (r *TlsResponse) RequiresCipher(ianaName string) bool {
+	_, found := r.FindCipherSuite(ianaName)
+	return found
+}
+
+// RequiresAnyCipher reports whether the host offers at least one of several cipher suites, under any of its
+// enabled TLS versions. The comparison is case-insensitive.
+//
+// Parameters:
+//   - ianaNames: The IANA names of the acceptable cipher suites.
+//
+// Returns:
+//   - `true` if any of the cipher suites was offered. `false` if `ianaNames` is empty.
+func (r *TlsResponse) RequiresAnyCipher(ianaNames ...string) bool {
+	for _, name := range ianaNames {
+		if r.RequiresCipher(name) {
+			return true
+		}
+	}
+
+	return false
+}
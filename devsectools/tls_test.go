@@ -0,0 +1,132 @@
+package devsectools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// emptyTLSResponses returns responses for hosts without TLS, in every shape the API (or a caller) may
+// produce them.
+func emptyTLSResponses(t *testing.T) map[string]*TlsResponse {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"hostname": "example.com", "tlsVersions": {}, "tlsConnections": []}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	fetched, err := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL})).TLS(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("TLS() error = %v", err)
+	}
+
+	responses := map[string]*TlsResponse{
+		"nil":           nil,
+		"zero":          {},
+		"TLS disabled":  fetched,
+		"null conns":    {},
+		"null suites":   {},
+		"omitted conns": {},
+	}
+
+	documents := map[string]string{
+		"null conns": `{"hostname": "example.com", "tlsConnections": null}`,
+		"null suites": `{"hostname": "example.com",
+			"tlsConnections": [{"version": "TLS 1.2", "cipherSuites": null}]}`,
+		"omitted conns": `{"hostname": "example.com"}`,
+	}
+	for name, doc := range documents {
+		if err := json.Unmarshal([]byte(doc), responses[name]); err != nil {
+			t.Fatalf("decoding %s: %v", name, err)
+		}
+	}
+
+	return responses
+}
+
+func TestTLSHelpersOnEmptyResponses(t *testing.T) {
+	t.Parallel()
+
+	for name, r := range emptyTLSResponses(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := r.EnabledVersions(); len(got) != 0 {
+				t.Errorf("EnabledVersions() = %v, want none", got)
+			}
+			if got := r.HighestTLSVersion(); got != 0 {
+				t.Errorf("HighestTLSVersion() = %v, want 0", got)
+			}
+			if got := r.DeprecatedTLSVersions(); len(got) != 0 {
+				t.Errorf("DeprecatedTLSVersions() = %v, want none", got)
+			}
+			if r.SupportsDeprecatedTLS() {
+				t.Error("SupportsDeprecatedTLS() = true, want false")
+			}
+			if suite, ok := r.FindCipherSuite("TLS_AES_128_GCM_SHA256"); ok || suite != nil {
+				t.Errorf("FindCipherSuite() = (%v, %t), want (nil, false)", suite, ok)
+			}
+			if got := r.CipherSuiteVersions("TLS_AES_128_GCM_SHA256"); len(got) != 0 {
+				t.Errorf("CipherSuiteVersions() = %v, want none", got)
+			}
+			if r.RequiresCipher("TLS_AES_128_GCM_SHA256") || r.RequiresAnyCipher("TLS_AES_128_GCM_SHA256") {
+				t.Error("RequiresCipher() or RequiresAnyCipher() = true, want false")
+			}
+			if got := r.StrengthByVersion(); len(got) != 0 {
+				t.Errorf("StrengthByVersion() = %v, want none", got)
+			}
+			if weakest, strongest, ok := r.StrengthInconsistency(); ok ||
+				weakest != (VersionStrength{}) || strongest != (VersionStrength{}) {
+				t.Errorf("StrengthInconsistency() = (%v, %v, %t), want zero values", weakest, strongest, ok)
+			}
+			if r.InconsistentStrength() {
+				t.Error("InconsistentStrength() = true, want false")
+			}
+
+			result := r.CheckPolicy(ModernPolicy)
+			if result.Passed || len(result.Violations) == 0 {
+				t.Errorf("CheckPolicy() = %+v, want a failure for a host without TLS", result)
+			}
+
+			scan := &FullScan{TLS: r}
+			if got := scan.SecurityScore(); got != 0 {
+				t.Errorf("SecurityScore() = %d, want 0", got)
+			}
+
+			_ = r.Canonical()
+		})
+	}
+}
+
+func TestHelpersOnNilResponses(t *testing.T) {
+	t.Parallel()
+
+	var (
+		domainResp *DomainResponse
+		httpResp   *HttpResponse
+		tlsResp    *TlsResponse
+		scan       *FullScan
+	)
+
+	if domainResp.IsValid() {
+		t.Error("DomainResponse.IsValid() = true, want false")
+	}
+	if _, err := domainResp.RegistrableDomain(); err == nil {
+		t.Error("DomainResponse.RegistrableDomain() error = nil, want an error")
+	}
+	if got := httpResp.Canonical(); got != "" {
+		t.Errorf("HttpResponse.Canonical() = %q, want empty", got)
+	}
+	if got := tlsResp.Canonical(); got != "" {
+		t.Errorf("TlsResponse.Canonical() = %q, want empty", got)
+	}
+	if got := scan.SecurityScore(); got != 0 || scan.PassesThreshold(1) {
+		t.Errorf("FullScan.SecurityScore() = %d, want 0 and a failed threshold", got)
+	}
+	if got := (&FullScan{}).SecurityScore(); got != 0 {
+		t.Errorf("SecurityScore() of an empty scan = %d, want 0", got)
+	}
+}
@@ -0,0 +1,154 @@
+package devsectools
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultRefreshBefore is how long before a token expires `CachingTokenProvider` refreshes it, unless
+// `RefreshBefore` is set.
+const DefaultRefreshBefore = time.Minute
+
+// TokenRefreshReason describes why `CachingTokenProvider` fetched a token.
+type TokenRefreshReason string
+
+// Reasons for fetching a token.
+const (
+	RefreshInitial  TokenRefreshReason = "initial"  // No token had been fetched yet.
+	RefreshExpiring TokenRefreshReason = "expiring" // The token was about to expire, or had expired.
+	RefreshRejected TokenRefreshReason = "rejected" // The API rejected the token with `401 Unauthorized`.
+)
+
+// TokenRefresh describes a token fetch by `CachingTokenProvider`, for logging and metrics.
+type TokenRefresh struct {
+	Reason    TokenRefreshReason // Why the token was fetched.
+	Time      time.Time          // When the fetch completed.
+	ExpiresAt time.Time          // When the new token expires, if the fetch succeeded.
+	Err       error              // The error, if the fetch failed.
+}
+
+// CachingTokenProvider is a TokenProvider for short-lived tokens. It caches the token and fetches a new
+// one shortly before it expires, so requests don't wait for a refresh or fail with an expired token. If the
+// API rejects a token with `401 Unauthorized`, the client discards it and retries the request once with a
+// new one. Concurrent callers share a single fetch.
+//
+// If a proactive refresh fails while the cached token is still valid, the cached token keeps being used,
+// and the fetch is attempted again on the next request.
+type CachingTokenProvider struct {
+	// Fetch obtains a new token and its expiry. A zero expiry means the token never expires.
+	Fetch func(ctx context.Context) (token string, expiresAt time.Time, err error)
+
+	// RefreshBefore is how long before expiry to fetch a new token. Zero uses `DefaultRefreshBefore`.
+	RefreshBefore time.Duration
+
+	// OnRefresh, if set, is called after every fetch, whether it succeeded or not. It is called while
+	// holding the provider's lock, so it must not call `Token`.
+	OnRefresh func(TokenRefresh)
+
+	clock     clock // Set by `Client.setClock` in tests; nil uses the real clock.
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewCachingTokenProvider creates a CachingTokenProvider.
+//
+// Parameters:
+//   - fetch: A function which obtains a new token and its expiry.
+//
+// Returns:
+//   - A pointer to the newly created CachingTokenProvider.
+func NewCachingTokenProvider(
+	fetch func(ctx context.Context) (token string, expiresAt time.Time, err error),
+) *CachingTokenProvider {
+	return &CachingTokenProvider{Fetch: fetch}
+}
+
+// Token implements `TokenProvider`, returning the cached token, or fetching a new one if there is none or
+// it expires within `RefreshBefore`.
+//
+// Parameters:
+//   - ctx: A context to allow cancelling a fetch.
+//
+// Returns:
+//   - A valid token.
+//   - An error if a fetch was needed and failed, and there is no valid cached token.
+func (p *CachingTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+
+	refreshBefore := p.RefreshBefore
+	if refreshBefore <= 0 {
+		refreshBefore = DefaultRefreshBefore
+	}
+
+	switch {
+	case p.token == "":
+		return p.fetch(ctx, RefreshInitial)
+	case p.expiresAt.IsZero() || now.Before(p.expiresAt.Add(-refreshBefore)):
+		return p.token, nil
+	}
+
+	token, err := p.fetch(ctx, RefreshExpiring)
+	if err != nil && now.Before(p.expiresAt) {
+		return p.token, nil
+	}
+
+	return token, err
+}
+
+// invalidate discards the cached token after the API rejected it, and fetches a new one, unless it has
+// already been replaced since `stale` was read.
+//
+// Parameters:
+//   - ctx: A context to allow cancelling the fetch.
+//   - stale: The token which the API rejected.
+//
+// Returns:
+//   - An error if the token could not be fetched.
+func (p *CachingTokenProvider) invalidate(ctx context.Context, stale string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != stale {
+		return nil
+	}
+
+	_, err := p.fetch(ctx, RefreshRejected)
+
+	return err
+}
+
+// fetch obtains a new token and caches it. The lock must be held.
+//
+// Parameters:
+//   - ctx: A context to allow cancelling the fetch.
+//   - reason: Why the token is being fetched, for `OnRefresh`.
+//
+// Returns:
+//   - The new token.
+//   - An error if the token could not be fetched.
+func (p *CachingTokenProvider) fetch(ctx context.Context, reason TokenRefreshReason) (string, error) {
+	token, expiresAt, err := p.Fetch(ctx)
+	if err == nil {
+		p.token, p.expiresAt = token, expiresAt
+	}
+
+	if p.OnRefresh != nil {
+		p.OnRefresh(TokenRefresh{Reason: reason, Time: p.now(), ExpiresAt: expiresAt, Err: err})
+	}
+
+	return token, err
+}
+
+// now returns the current time from the provider's clock.
+func (p *CachingTokenProvider) now() time.Time {
+	if p.clock == nil {
+		return time.Now()
+	}
+
+	return p.clock.Now()
+}
@@ -0,0 +1,226 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenServer is a test server which records the bearer token of every request, and rejects the tokens
+// listed in `reject` with `401 Unauthorized`.
+type tokenServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	seen   []string
+	reject map[string]bool
+}
+
+// newTokenServer starts a tokenServer which rejects the given tokens.
+func newTokenServer(t *testing.T, reject ...string) *tokenServer {
+	t.Helper()
+
+	ts := &tokenServer{reject: make(map[string]bool)}
+	for _, token := range reject {
+		ts.reject["Bearer "+token] = true
+	}
+
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+
+		ts.mu.Lock()
+		ts.seen = append(ts.seen, auth)
+		rejected := ts.reject[auth]
+		ts.mu.Unlock()
+
+		if rejected {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "token expired"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+// Seen returns the `Authorization` header of every request, in order.
+func (ts *tokenServer) Seen() []string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	return slices.Clone(ts.seen)
+}
+
+// tokenFixture is a CachingTokenProvider which issues "t1", "t2", ... valid for `ttl` on a fake clock,
+// and records the reason of every refresh.
+type tokenFixture struct {
+	provider *CachingTokenProvider
+	clock    *fakeClock
+	fetches  atomic.Int32
+
+	mu      sync.Mutex
+	reasons []TokenRefreshReason
+}
+
+// newTokenFixture creates a client for `srv` whose tokens come from a tokenFixture.
+func newTokenFixture(srv *httptest.Server, ttl time.Duration) (*Client, *tokenFixture) {
+	f := &tokenFixture{clock: newFakeClock()}
+	f.provider = NewCachingTokenProvider(func(context.Context) (string, time.Time, error) {
+		n := f.fetches.Add(1)
+		return fmt.Sprintf("t%d", n), f.clock.Now().Add(ttl), nil
+	})
+	f.provider.OnRefresh = func(r TokenRefresh) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		f.reasons = append(f.reasons, r.Reason)
+	}
+
+	c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}), WithTokenProvider(f.provider))
+	c.setClock(f.clock)
+
+	return c, f
+}
+
+// Reasons returns the reason of every refresh, in order.
+func (f *tokenFixture) Reasons() []TokenRefreshReason {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.reasons)
+}
+
+func TestCachingTokenProviderRefreshesBeforeExpiry(t *testing.T) {
+	t.Parallel()
+
+	srv := newTokenServer(t)
+	c, f := newTokenFixture(srv.Server, 5*time.Minute)
+
+	// The token is reused until `DefaultRefreshBefore` ahead of its expiry, then replaced.
+	for _, advance := range []time.Duration{0, 3 * time.Minute, time.Minute, time.Minute} {
+		f.clock.Advance(advance)
+		if _, err := c.TLS(context.Background(), "example.com"); err != nil {
+			t.Fatalf("TLS() error = %v", err)
+		}
+	}
+
+	if got, want := srv.Seen(), []string{"Bearer t1", "Bearer t1", "Bearer t2", "Bearer t2"}; !slices.Equal(got, want) {
+		t.Errorf("tokens sent = %v, want %v", got, want)
+	}
+	if got, want := f.Reasons(), []TokenRefreshReason{RefreshInitial, RefreshExpiring}; !slices.Equal(got, want) {
+		t.Errorf("refresh reasons = %v, want %v", got, want)
+	}
+}
+
+func TestCachingTokenProviderRetriesOnceOn401(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejected once", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newTokenServer(t, "t1")
+		c, f := newTokenFixture(srv.Server, time.Hour)
+
+		if _, err := c.TLS(context.Background(), "example.com"); err != nil {
+			t.Fatalf("TLS() error = %v", err)
+		}
+		if got, want := srv.Seen(), []string{"Bearer t1", "Bearer t2"}; !slices.Equal(got, want) {
+			t.Errorf("tokens sent = %v, want %v", got, want)
+		}
+		if got, want := f.Reasons(), []TokenRefreshReason{RefreshInitial, RefreshRejected}; !slices.Equal(got, want) {
+			t.Errorf("refresh reasons = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("rejected again", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newTokenServer(t, "t1", "t2", "t3")
+		c, _ := newTokenFixture(srv.Server, time.Hour)
+
+		_, err := c.TLS(context.Background(), "example.com")
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("TLS() error = %v, want a 401", err)
+		}
+		if got := len(srv.Seen()); got != 2 {
+			t.Errorf("server received %d requests, want the request and a single retry", got)
+		}
+	})
+}
+
+func TestCachingTokenProviderSharesFetches(t *testing.T) {
+	t.Parallel()
+
+	srv := newTokenServer(t)
+	c, f := newTokenFixture(srv.Server, time.Hour)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.TLS(context.Background(), "example.com"); err != nil {
+				t.Errorf("TLS() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := f.fetches.Load(); got != 1 {
+		t.Errorf("token fetched %d times, want 1", got)
+	}
+}
+
+func TestCachingTokenProviderKeepsValidTokenOnFailedRefresh(t *testing.T) {
+	t.Parallel()
+
+	clk := newFakeClock()
+	errUnavailable := errors.New("identity provider unavailable")
+
+	var refreshes []TokenRefresh
+	fail := false
+	provider := NewCachingTokenProvider(func(context.Context) (string, time.Time, error) {
+		if fail {
+			return "", time.Time{}, errUnavailable
+		}
+		return "t1", clk.Now().Add(5 * time.Minute), nil
+	})
+	provider.OnRefresh = func(r TokenRefresh) { refreshes = append(refreshes, r) }
+	provider.clock = clk
+
+	if _, err := provider.Token(context.Background()); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+
+	// Within `RefreshBefore` of expiry the refresh fails, but the token is still valid.
+	fail = true
+	clk.Advance(4*time.Minute + 30*time.Second)
+	if token, err := provider.Token(context.Background()); err != nil || token != "t1" {
+		t.Errorf("Token() = (%q, %v), want the cached t1", token, err)
+	}
+
+	// Once it has expired, the failure is returned.
+	clk.Advance(time.Minute)
+	if _, err := provider.Token(context.Background()); !errors.Is(err, errUnavailable) {
+		t.Errorf("Token() error = %v, want %v", err, errUnavailable)
+	}
+
+	if len(refreshes) != 3 || refreshes[1].Reason != RefreshExpiring || !errors.Is(refreshes[1].Err, errUnavailable) {
+		t.Errorf("refreshes = %+v, want initial, then two failed expiring refreshes", refreshes)
+	}
+	if !refreshes[0].Time.Equal(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("initial refresh time = %s, want the fake clock's time", refreshes[0].Time)
+	}
+}
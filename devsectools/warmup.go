@@ -0,0 +1,136 @@
+package devsectools
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Warmup opens up to `n` keep-alive connections to the API ahead of a burst of requests, so that the first
+// requests of a large batch don't all pay the TCP and TLS handshake cost at once. It sends `n` concurrent
+// HEAD requests to the endpoint's base URL, including its `PathPrefix`; any HTTP response counts as
+// success. With `Config.Offline`, or if `n` isn't positive, it does nothing.
+//
+// With `Config.EndpointSelector`, pass the batch's scan targets (or one per region) to warm the endpoints
+// selected for them instead: `n` connections are opened to each distinct endpoint.
+//
+// Only `Config.MaxIdleConnsPerHost` connections are kept once the requests finish (2 by default), so set
+// it to at least `n` for the warm connections to survive until the batch starts.
+//
+// Parameters:
+//   - ctx: Context for handling timeouts and cancellations.
+//   - n: The number of connections to open to each endpoint. Values <= 0 open none.
+//   - targets: Optional scan targets (e.g., "example.com") whose selected endpoints are warmed. Without
+//     them, the client's endpoint is warmed.
+//
+// Returns:
+//   - An error joining every failed connection attempt, or `nil` if all succeeded.
+func (c *Client) Warmup(ctx context.Context, n int, targets ...string) error {
+	if c.closed.Load() {
+		return ErrClientClosed
+	}
+	if c.config.Offline || n <= 0 {
+		return nil
+	}
+
+	bases, err := c.warmupURLs(targets)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout())
+	defer cancel()
+
+	errs := make([]error, n*len(bases))
+
+	var wg sync.WaitGroup
+	for i, base := range bases {
+		for j := range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i*n+j] = c.warmOne(ctx, base)
+			}()
+		}
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// warmupURLs returns the distinct base URLs of the endpoints which requests for the targets are sent to.
+//
+// Parameters:
+//   - targets: The scan targets. Without any, the client's endpoint is used.
+//
+// Returns:
+//   - The base URLs, including their path prefixes.
+//   - An error if a target or an endpoint's base URL is invalid.
+func (c *Client) warmupURLs(targets []string) ([]*url.URL, error) {
+	if len(targets) == 0 {
+		base, err := c.endpoint().url()
+		if err != nil {
+			return nil, err
+		}
+
+		return []*url.URL{base}, nil
+	}
+
+	seen := make(map[string]bool, len(targets))
+	bases := make([]*url.URL, 0, len(targets))
+
+	for _, target := range targets {
+		normalized, err := c.normalizeTarget(target)
+		if err != nil {
+			return nil, err
+		}
+
+		ro := &requestOptions{query: scanQuery(normalized)}
+		c.selectEndpoint(ro)
+
+		endpoint := c.endpoint()
+		if ro.endpoint != nil {
+			endpoint = ro.endpoint
+		}
+
+		base, err := endpoint.url()
+		if err != nil {
+			return nil, err
+		}
+
+		if key := base.String(); !seen[key] {
+			seen[key] = true
+			bases = append(bases, base)
+		}
+	}
+
+	return bases, nil
+}
+
+// warmOne sends a single HEAD request to the endpoint's base URL and releases the connection for reuse.
+//
+// Parameters:
+//   - ctx: Context for handling timeouts and cancellations.
+//   - base: The endpoint's base URL, including its path prefix.
+//
+// Returns:
+//   - An error if no HTTP response was received.
+func (c *Client) warmOne(ctx context.Context, base *url.URL) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, base.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(io.Discard, resp.Body)
+
+	return err
+}
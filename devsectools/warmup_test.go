@@ -0,0 +1,113 @@
+package devsectools
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWarmup(t *testing.T) {
+	t.Parallel()
+
+	var heads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || r.URL.Path != "/v1" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+
+		heads.Add(1)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL, PathPrefix: "/v1"}))
+
+	if err := c.Warmup(context.Background(), 3); err != nil {
+		t.Fatalf("Warmup() error = %v", err)
+	}
+	if n := heads.Load(); n != 3 {
+		t.Errorf("server received %d HEAD requests to the path prefix, want 3", n)
+	}
+}
+
+func TestWarmupOffline(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		requests.Add(1)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClientWithConfig(&Config{Endpoint: &Endpoint{BaseURL: srv.URL}, Timeout: DefaultTimeout, Offline: true})
+
+	if err := c.Warmup(context.Background(), 2); err != nil {
+		t.Fatalf("Warmup() error = %v", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("server received %d requests in offline mode", n)
+	}
+}
+
+func TestWarmupNonPositive(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		requests.Add(1)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+
+	for _, n := range []int{0, -1} {
+		if err := c.Warmup(context.Background(), n); err != nil {
+			t.Errorf("Warmup(%d) error = %v", n, err)
+		}
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("server received %d requests, want none", got)
+	}
+}
+
+// BenchmarkFirstRequest measures the latency of a new client's first request over TLS, with and without
+// warming the connection pool first. Warming happens outside the timed section, as it would before a batch.
+func BenchmarkFirstRequest(b *testing.B) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	b.Cleanup(srv.Close)
+
+	tlsConfig := &tls.Config{RootCAs: serverRoots(srv), MinVersion: tls.VersionTLS12}
+
+	for _, warm := range []bool{false, true} {
+		name := "cold"
+		if warm {
+			name = "warm"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			for range b.N {
+				b.StopTimer()
+				c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}), WithTLSConfig(tlsConfig))
+				if warm {
+					if err := c.Warmup(context.Background(), 1); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.StartTimer()
+
+				if _, err := c.TLS(context.Background(), "example.com"); err != nil {
+					b.Fatal(err)
+				}
+
+				b.StopTimer()
+				_ = c.Close()
+				b.StartTimer()
+			}
+		})
+	}
+}
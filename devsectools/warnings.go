@@ -0,0 +1,104 @@
+package devsectools
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Warning codes reported in a `Warning`.
+const (
+	WarnDeprecatedTLS        = "deprecated-tls"        // The host supports TLS 1.0 or TLS 1.1.
+	WarnInconsistentStrength = "inconsistent-strength" // Some TLS versions offer only weak cipher suites.
+	WarnHostnameMismatch     = "hostname-mismatch"     // The API scanned a different host than requested.
+	WarnUnexpectedStatus     = "unexpected-status"     // A result was decoded from a status other than 200.
+)
+
+// Warning describes a condition which deserves attention but isn't a failure, so that tools can show it
+// distinctly from errors.
+type Warning struct {
+	Code    string // A stable identifier for the condition (e.g., `WarnDeprecatedTLS`).
+	Message string // A human-readable description.
+}
+
+// String returns the warning in the form "code: message".
+func (w Warning) String() string {
+	return w.Code + ": " + w.Message
+}
+
+// scanWarnings finds the non-fatal conditions in a full scan.
+//
+// Parameters:
+//   - scan: The scan to inspect. Scans which failed are skipped.
+//
+// Returns:
+//   - The warnings found, in a stable order. Empty if there are none.
+func (c *Client) scanWarnings(scan *FullScan) []Warning {
+	var warnings []Warning
+
+	if versions := scan.TLS.DeprecatedTLSVersions(); len(versions) > 0 {
+		names := make([]string, len(versions))
+		for i, v := range versions {
+			names[i] = v.String()
+		}
+		warnings = append(warnings, Warning{
+			Code:    WarnDeprecatedTLS,
+			Message: "deprecated TLS versions are enabled: " + strings.Join(names, ", "),
+		})
+	}
+
+	if weakest, strongest, inconsistent := scan.TLS.StrengthInconsistency(); inconsistent {
+		warnings = append(warnings, Warning{
+			Code: WarnInconsistentStrength,
+			Message: fmt.Sprintf(
+				"the strongest cipher suite under %s is %s, but under %s it is %s",
+				weakest.Version, weakest.Strongest, strongest.Version, strongest.Strongest,
+			),
+		})
+	}
+
+	// The API reports the host it actually scanned, which can differ from the request after redirects or
+	// if the target was misparsed.
+	if target, err := c.normalizeTarget(scan.URL); err == nil {
+		_, requested, _ := splitHost(target)
+		requested = strings.TrimSuffix(strings.ToLower(requested), ".")
+
+		scanned := make(map[ScanMethod]string)
+		if scan.Domain != nil {
+			scanned[MethodDomain] = scan.Domain.Hostname
+		}
+		if scan.HTTP != nil {
+			scanned[MethodHTTP] = scan.HTTP.Hostname
+		}
+		if scan.TLS != nil {
+			scanned[MethodTLS] = scan.TLS.Hostname
+		}
+
+		for _, method := range []ScanMethod{MethodDomain, MethodHTTP, MethodTLS} {
+			hostname := strings.TrimSuffix(strings.ToLower(scanned[method]), ".")
+			if hostname != "" && hostname != requested {
+				warnings = append(warnings, Warning{
+					Code: WarnHostnameMismatch,
+					Message: fmt.Sprintf(
+						"the %s scan reports host %q, but %q was requested", method, hostname, requested,
+					),
+				})
+			}
+		}
+	}
+
+	// Statuses allowed by `Config.SuccessStatuses` are decoded, but anything other than 200 may mean a
+	// partial or provisional result.
+	for _, method := range []ScanMethod{MethodDomain, MethodHTTP, MethodTLS} {
+		if status, ok := scan.statuses[method]; ok && status != http.StatusOK {
+			warnings = append(warnings, Warning{
+				Code: WarnUnexpectedStatus,
+				Message: fmt.Sprintf(
+					"the %s scan was decoded from a %d %s response", method, status, http.StatusText(status),
+				),
+			})
+		}
+	}
+
+	return warnings
+}
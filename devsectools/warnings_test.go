@@ -0,0 +1,141 @@
+package devsectools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+// scanReply is a test server's answer to one scan endpoint.
+type scanReply struct {
+	status int
+	body   string
+}
+
+// newScanServer starts a test server which answers each scan endpoint ("/domain", "/http", "/tls") with
+// its reply in `replies`, or with an empty 200 result.
+func newScanServer(t *testing.T, replies map[string]scanReply) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reply, ok := replies[r.URL.Path]
+		if !ok {
+			reply = scanReply{http.StatusOK, `{"hostname": "example.com"}`}
+		}
+
+		w.WriteHeader(reply.status)
+		_, _ = w.Write([]byte(reply.body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestScanWarnings(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		replies map[string]scanReply
+		want    []string // Warning codes, in order.
+	}{
+		"none": {nil, nil},
+		"deprecated TLS": {
+			map[string]scanReply{"/tls": {200, `{"hostname": "example.com", "tlsVersions": {"tls10": true}}`}},
+			[]string{WarnDeprecatedTLS},
+		},
+		"inconsistent strength": {
+			map[string]scanReply{"/tls": {200, `{"hostname": "example.com", "tlsConnections": [
+				{"version": "TLS 1.2", "versionId": 771, "cipherSuites": [{"strength": "weak"}]},
+				{"version": "TLS 1.3", "versionId": 772, "cipherSuites": [{"strength": "recommended"}]}
+			]}`}},
+			[]string{WarnInconsistentStrength},
+		},
+		"hostname mismatch": {
+			map[string]scanReply{"/http": {200, `{"hostname": "www.example.com"}`}},
+			[]string{WarnHostnameMismatch},
+		},
+		"unexpected status": {
+			map[string]scanReply{"/domain": {http.StatusPartialContent, `{"hostname": "example.com"}`}},
+			[]string{WarnUnexpectedStatus},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newScanServer(t, tt.replies)
+			c := NewClientWithConfig(&Config{
+				Endpoint:        &Endpoint{BaseURL: srv.URL},
+				Timeout:         DefaultTimeout,
+				SuccessStatuses: []int{http.StatusOK, http.StatusPartialContent},
+				EnableWarnings:  true,
+			})
+
+			scan, err := c.ScanAll(context.Background(), "example.com")
+			if err != nil {
+				t.Fatalf("ScanAll() error = %v", err)
+			}
+
+			if got := warningCodes(scan.Warnings); !slices.Equal(got, tt.want) {
+				t.Errorf("ScanAll() warnings = %v, want codes %v", scan.Warnings, tt.want)
+			}
+
+			scans, _ := c.ScanFleet(context.Background(), []string{"example.com"}, BatchOptions{})
+			if got := warningCodes(scans["example.com"].Warnings); !slices.Equal(got, tt.want) {
+				t.Errorf("ScanFleet() warnings = %v, want codes %v", scans["example.com"].Warnings, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanWarningsDisabled(t *testing.T) {
+	t.Parallel()
+
+	srv := newScanServer(t, map[string]scanReply{"/http": {200, `{"hostname": "www.example.com"}`}})
+	c := NewClient(WithEndpoint(&Endpoint{BaseURL: srv.URL}))
+
+	scan, err := c.ScanAll(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("ScanAll() error = %v", err)
+	}
+	if scan.Warnings != nil {
+		t.Errorf("ScanAll() warnings = %v without EnableWarnings, want none", scan.Warnings)
+	}
+}
+
+func TestUnexpectedStatusNotWarnedFromCache(t *testing.T) {
+	t.Parallel()
+
+	srv := newScanServer(t, map[string]scanReply{"/tls": {http.StatusPartialContent, `{"hostname": "example.com"}`}})
+	c := NewClientWithConfig(&Config{
+		Endpoint:        &Endpoint{BaseURL: srv.URL},
+		Timeout:         DefaultTimeout,
+		SuccessStatuses: []int{http.StatusOK, http.StatusPartialContent},
+		EnableWarnings:  true,
+		Cache:           NewMemoryCache(time.Hour),
+	})
+
+	for i, want := range [][]string{{WarnUnexpectedStatus}, nil} {
+		scan, err := c.ScanAll(context.Background(), "example.com")
+		if err != nil {
+			t.Fatalf("ScanAll() error = %v", err)
+		}
+		if got := warningCodes(scan.Warnings); !slices.Equal(got, want) {
+			t.Errorf("ScanAll() call %d warnings = %v, want codes %v", i+1, scan.Warnings, want)
+		}
+	}
+}
+
+// warningCodes returns the code of each warning, in order.
+func warningCodes(warnings []Warning) []string {
+	var codes []string
+	for _, w := range warnings {
+		codes = append(codes, w.Code)
+	}
+
+	return codes
+}
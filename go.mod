@@ -1,3 +1,17 @@
 module github.com/northwood-labs/devsec-tools-sdk-go
 
 go 1.23.0
+
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/zalando/go-keyring v0.2.6
+	golang.org/x/net v0.38.0
+)
+
+require (
+	al.essio.dev/pkg/shellescape v1.5.1 // indirect
+	github.com/danieljoos/wincred v1.2.2 // indirect
+	github.com/godbus/dbus/v5 v5.1.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
+)